@@ -0,0 +1,28 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+)
+
+// buildAcList - builds the list of access controls sent to the grid for an access control attribute, replacing the
+// named ACLs it lists by name with their WAPI reference
+func buildAcList(infobloxClient *skyinfoblox.InfobloxClient, acList []interface{}) ([]interface{}, error) {
+	builtAc := util.BuildAcList(acList)
+	for idx, value := range builtAc {
+		name, ok := value.(util.NamedACL)
+		if !ok {
+			continue
+		}
+		namedACLs := new([]struct {
+			Reference string `json:"_ref"`
+		})
+		err := searchSingleObject(infobloxClient, "namedacl", map[string]string{"name": string(name)}, []string{"name"}, namedACLs)
+		if err != nil {
+			return nil, fmt.Errorf("Infoblox Named ACL %s lookup Error: %s", name, err)
+		}
+		builtAc[idx] = (*namedACLs)[0].Reference
+	}
+	return builtAc, nil
+}
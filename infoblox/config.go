@@ -14,6 +14,18 @@ type Config struct {
 	IBXServer   string
 }
 
+// providerMeta - what a configured provider hands to its resources and data sources: the client of the grid along
+// with the provider settings and what the grid offers, which live as long as the provider
+type providerMeta struct {
+	client *skyinfoblox.InfobloxClient
+	// defaultExtAttrs - the default_extattrs of the provider, merged into the extattrs of every resource
+	defaultExtAttrs map[string]interface{}
+	// capabilities - what the WAPI version of the provider supports
+	capabilities *wapiCapabilities
+	// extAttrTypes - the types of the extensible attribute definitions of the grid
+	extAttrTypes extAttrTypeCache
+}
+
 // Client returns a new client for accessing Infoblox server
 func (c *Config) Client() (*skyinfoblox.InfobloxClient, error) {
 	log.Printf("[INFO] Infoblox Client configured for URL: %s", c.IBXServer)
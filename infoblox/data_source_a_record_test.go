@@ -0,0 +1,59 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccDataSourceARecord(t *testing.T) {
+
+	randInt := acctest.RandInt()
+	recordName := fmt.Sprintf("a-record-datasource-%d.slupaas.bskyb.com", randInt)
+	dataSourceName := "data.infoblox_arecord.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceARecordTemplate(recordName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "ref", "infoblox_arecord.acctest", "ref"),
+					resource.TestCheckResourceAttr(dataSourceName, "name", recordName),
+					resource.TestCheckResourceAttr(dataSourceName, "address", "10.0.0.11"),
+					resource.TestCheckResourceAttr(dataSourceName, "view", "default"),
+					resource.TestCheckResourceAttr(dataSourceName, "ttl", "900"),
+				),
+			},
+			{
+				Config:      testAccDataSourceARecordNotFoundTemplate(recordName),
+				ExpectError: regexp.MustCompile(`no record:a found matching`),
+			},
+		},
+	})
+}
+
+func testAccDataSourceARecordTemplate(recordName string) string {
+	return fmt.Sprintf(`
+resource "infoblox_arecord" "acctest" {
+  name = "%s"
+  address = "10.0.0.11"
+  ttl = 900
+}
+
+data "infoblox_arecord" "acctest" {
+  name = "${infoblox_arecord.acctest.name}"
+  view = "default"
+}`, recordName)
+}
+
+func testAccDataSourceARecordNotFoundTemplate(recordName string) string {
+	return fmt.Sprintf(`
+data "infoblox_arecord" "acctest" {
+  name = "missing-%s"
+  view = "default"
+}`, recordName)
+}
@@ -0,0 +1,86 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox/api/records"
+)
+
+func dataSourceCNAMERecord() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCNAMERecordRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "name of the CNAME record to look up",
+			},
+			"canonical": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "canonical name of the CNAME record to look up",
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "DNS Zone of the CNAME record to look up",
+			},
+			"view": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "DNS View of the CNAME record to look up",
+			},
+			"ttl": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "TTL in seconds for the record",
+			},
+			"use_ttl": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ref": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Internal Reference for the record",
+			},
+		},
+	}
+}
+
+func dataSourceCNAMERecordRead(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	searchFields := dataSourceSearchFields(d, map[string]string{
+		"name":      "name",
+		"canonical": "canonical",
+		"zone":      "zone",
+		"view":      "view",
+	})
+	fields := []string{"name", "canonical", "zone", "view", "ttl", "use_ttl", "comment"}
+	found := new([]records.CNAMERecord)
+	err := searchSingleObject(infobloxClient, "record:cname", searchFields, fields, found)
+	if err != nil {
+		return fmt.Errorf("Infoblox Read Error: %s", err)
+	}
+
+	record := (*found)[0]
+	d.SetId(record.Ref)
+	d.Set("name", record.Name)
+	d.Set("canonical", record.Canonical)
+	d.Set("zone", record.Zone)
+	d.Set("view", record.View)
+	d.Set("ttl", record.TTL)
+	d.Set("use_ttl", record.UseTTL)
+	d.Set("comment", record.Comment)
+	d.Set("ref", record.Ref)
+	return nil
+}
@@ -0,0 +1,48 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccDataSourceCNAMERecord(t *testing.T) {
+
+	randInt := acctest.RandInt()
+	cname := fmt.Sprintf("cname-datasource-%d.slupaas.bskyb.com", randInt)
+	canonical := fmt.Sprintf("canonical-datasource-%d.slupaas.bskyb.com", randInt)
+	dataSourceName := "data.infoblox_cname_record.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceCNAMERecordTemplate(cname, canonical),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "ref", "infoblox_cname_record.acctest", "ref"),
+					resource.TestCheckResourceAttr(dataSourceName, "name", cname),
+					resource.TestCheckResourceAttr(dataSourceName, "canonical", canonical),
+					resource.TestCheckResourceAttr(dataSourceName, "comment", "Terraform Acceptance Testing for CNAME data sources"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceCNAMERecordTemplate(cname, canonical string) string {
+	return fmt.Sprintf(`
+resource "infoblox_cname_record" "acctest" {
+  name = "%s"
+  comment = "Terraform Acceptance Testing for CNAME data sources"
+  canonical = "%s"
+  view = "default"
+  ttl = 600
+}
+
+data "infoblox_cname_record" "acctest" {
+  canonical = "${infoblox_cname_record.acctest.canonical}"
+  view = "default"
+}`, cname, canonical)
+}
@@ -0,0 +1,40 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox/api/network"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+)
+
+func dataSourceNetwork() *schema.Resource {
+	return &schema.Resource{
+		Read:   dataSourceNetworkRead,
+		Schema: util.DataSourceSchema(resourceNetwork().Schema, "network", "networkview"),
+	}
+}
+
+func dataSourceNetworkRead(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	searchFields := dataSourceSearchFields(d, map[string]string{
+		"network":     "network",
+		"networkview": "network_view",
+	})
+	found := new([]network.Network)
+	err := searchSingleObject(infobloxClient, "network", searchFields, nil, found)
+	if err != nil {
+		return fmt.Errorf("Infoblox Read Error: %s", err)
+	}
+
+	ref := (*found)[0].Ref
+	d.SetId(ref)
+	err = resourceNetworkRead(d, m)
+	if err != nil {
+		return err
+	}
+	// the resource Read clears the ID rather than failing when the network was removed since the search
+	if d.Id() == "" {
+		return fmt.Errorf("Infoblox Read Error: the network %s was not found", ref)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package infoblox
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/wapitest"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestAccDataSourceNetwork(t *testing.T) {
+	networkAddr := "10.1." + strconv.Itoa(acctest.RandIntRange(0, 255)) + ".0/24"
+	dataSourceName := "data.infoblox_network.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceNetworkTemplate(networkAddr),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "ref", "infoblox_network.acctest", "ref"),
+					resource.TestCheckResourceAttr(dataSourceName, "network", networkAddr),
+					resource.TestCheckResourceAttr(dataSourceName, "networkview", "default"),
+					resource.TestCheckResourceAttr(dataSourceName, "comment", "a network looked up by a data source"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceNetworkRemovedAfterSearch(t *testing.T) {
+	d, meta := testDataSourceRemovedAfterSearch(t, dataSourceNetwork(), "network", map[string]interface{}{"network": "10.0.0.0/24"})
+	err := dataSourceNetworkRead(d, meta)
+	if err == nil || !regexp.MustCompile(`the network .* was not found`).MatchString(err.Error()) {
+		t.Fatalf("Expected the data source to fail on the removed network, got %v", err)
+	}
+}
+
+// testDataSourceRemovedAfterSearch - returns the resource data and provider meta of a data source against a fake grid
+// holding a single object of objectType, which the grid removes as soon as a search returned it
+func testDataSourceRemovedAfterSearch(t *testing.T, dataSource *schema.Resource, objectType string, fields map[string]interface{}) (*schema.ResourceData, interface{}) {
+	fake := wapitest.NewServer()
+	t.Cleanup(fake.Close)
+	ref, err := fake.Create(objectType, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fake.ServeHTTP(w, r)
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/"+objectType) {
+			fake.Delete(ref)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	meta, err := configureClient(testProviderResourceData(t, server.URL, nil), context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema.TestResourceDataRaw(t, dataSource.Schema, fields), meta
+}
+
+func testAccDataSourceNetworkTemplate(networkAddr string) string {
+	return fmt.Sprintf(`
+resource "infoblox_network" "acctest" {
+  network = "%s"
+  comment = "a network looked up by a data source"
+}
+
+data "infoblox_network" "acctest" {
+  network = "${infoblox_network.acctest.network}"
+  networkview = "default"
+}`, networkAddr)
+}
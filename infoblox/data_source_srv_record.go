@@ -0,0 +1,101 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox/api/records"
+)
+
+func dataSourceSRVRecord() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSRVRecordRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "name of the SRV record to look up",
+			},
+			"target": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "target host of the SRV record to look up",
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "DNS Zone of the SRV record to look up",
+			},
+			"view": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "DNS View of the SRV record to look up",
+			},
+			"port": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"priority": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"weight": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"ttl": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "TTL in seconds for the record",
+			},
+			"use_ttl": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ref": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Internal Reference for the record",
+			},
+		},
+	}
+}
+
+func dataSourceSRVRecordRead(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	searchFields := dataSourceSearchFields(d, map[string]string{
+		"name":   "name",
+		"target": "target",
+		"zone":   "zone",
+		"view":   "view",
+	})
+	fields := []string{"name", "target", "port", "priority", "weight", "zone", "view", "ttl", "use_ttl", "comment"}
+	found := new([]records.SRVRecord)
+	err := searchSingleObject(infobloxClient, "record:srv", searchFields, fields, found)
+	if err != nil {
+		return fmt.Errorf("Infoblox Read Error: %s", err)
+	}
+
+	record := (*found)[0]
+	d.SetId(record.Ref)
+	d.Set("name", record.Name)
+	d.Set("target", record.Target)
+	d.Set("port", record.Port)
+	d.Set("priority", record.Priority)
+	d.Set("weight", record.Weight)
+	d.Set("zone", record.Zone)
+	d.Set("view", record.View)
+	d.Set("ttl", record.TTL)
+	d.Set("use_ttl", record.UseTTL)
+	d.Set("comment", record.Comment)
+	d.Set("ref", record.Ref)
+	return nil
+}
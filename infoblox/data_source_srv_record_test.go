@@ -0,0 +1,48 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccDataSourceSRVRecord(t *testing.T) {
+
+	randInt := acctest.RandInt()
+	recordName := fmt.Sprintf("srv-datasource-%d.slupaas.bskyb.com", randInt)
+	dataSourceName := "data.infoblox_srv_record.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSRVRecordTemplate(recordName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "ref", "infoblox_srv_record.acctest", "ref"),
+					resource.TestCheckResourceAttr(dataSourceName, "name", recordName),
+					resource.TestCheckResourceAttr(dataSourceName, "port", "8080"),
+					resource.TestCheckResourceAttr(dataSourceName, "priority", "99"),
+					resource.TestCheckResourceAttr(dataSourceName, "weight", "10"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceSRVRecordTemplate(recordName string) string {
+	return fmt.Sprintf(`
+resource "infoblox_srv_record" "acctest" {
+  name = "%s"
+  port = 8080
+  priority = 99
+  target = "craig4test.testzone.slupaas.bskyb.com"
+  weight = 10
+}
+
+data "infoblox_srv_record" "acctest" {
+  name = "${infoblox_srv_record.acctest.name}"
+  target = "${infoblox_srv_record.acctest.target}"
+}`, recordName)
+}
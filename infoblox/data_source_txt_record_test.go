@@ -0,0 +1,47 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"testing"
+)
+
+func TestAccDataSourceTXTRecord(t *testing.T) {
+
+	randInt := acctest.RandInt()
+	recordName := fmt.Sprintf("txt-datasource-%d.slupaas.bskyb.com", randInt)
+	dataSourceName := "data.infoblox_txt_record.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceTXTRecordTemplate(recordName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", "infoblox_txtrecord.acctest", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "name", recordName),
+					resource.TestCheckResourceAttr(dataSourceName, "text", "data source lookup"),
+					resource.TestCheckResourceAttr(dataSourceName, "ttl", "300"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceTXTRecordTemplate(recordName string) string {
+	return fmt.Sprintf(`
+resource "infoblox_txtrecord" "acctest" {
+  name = "%s"
+  text = "data source lookup"
+  view = "default"
+  ttl = 300
+  use_ttl = true
+}
+
+data "infoblox_txt_record" "acctest" {
+  name = "${infoblox_txtrecord.acctest.name}"
+  text = "${infoblox_txtrecord.acctest.text}"
+}`, recordName)
+}
@@ -0,0 +1,40 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox/api/zoneauth"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+)
+
+func dataSourceZoneAuth() *schema.Resource {
+	return &schema.Resource{
+		Read:   dataSourceZoneAuthRead,
+		Schema: util.DataSourceSchema(resourceZoneAuth().Schema, "fqdn", "view"),
+	}
+}
+
+func dataSourceZoneAuthRead(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	searchFields := dataSourceSearchFields(d, map[string]string{
+		"fqdn": "fqdn",
+		"view": "view",
+	})
+	found := new([]zoneauth.DNSZone)
+	err := searchSingleObject(infobloxClient, "zone_auth", searchFields, nil, found)
+	if err != nil {
+		return fmt.Errorf("Infoblox Read Error: %s", err)
+	}
+
+	ref := (*found)[0].Reference
+	d.SetId(ref)
+	err = resourceZoneAuthRead(d, m)
+	if err != nil {
+		return err
+	}
+	// the resource Read clears the ID rather than failing when the zone was removed since the search
+	if d.Id() == "" {
+		return fmt.Errorf("Infoblox Read Error: the zone %s was not found", ref)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccDataSourceZoneAuth(t *testing.T) {
+	fqdn := fmt.Sprintf("datasource-%d.slupaas.bskyb.com", acctest.RandInt())
+	dataSourceName := "data.infoblox_zone_auth.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceZoneAuthTemplate(fqdn),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "ref", "infoblox_zone_auth.acctest", "ref"),
+					resource.TestCheckResourceAttr(dataSourceName, "fqdn", fqdn),
+					resource.TestCheckResourceAttr(dataSourceName, "view", "default"),
+					resource.TestCheckResourceAttr(dataSourceName, "soa_default_ttl", "3600"),
+					resource.TestCheckResourceAttr(dataSourceName, "comment", "a zone looked up by a data source"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceZoneAuthRemovedAfterSearch(t *testing.T) {
+	d, meta := testDataSourceRemovedAfterSearch(t, dataSourceZoneAuth(), "zone_auth", map[string]interface{}{"fqdn": "example.com"})
+	err := dataSourceZoneAuthRead(d, meta)
+	if err == nil || !regexp.MustCompile(`the zone .* was not found`).MatchString(err.Error()) {
+		t.Fatalf("Expected the data source to fail on the removed zone, got %v", err)
+	}
+}
+
+func testAccDataSourceZoneAuthTemplate(fqdn string) string {
+	return fmt.Sprintf(`
+resource "infoblox_zone_auth" "acctest" {
+  fqdn = "%s"
+  comment = "a zone looked up by a data source"
+  zone_format = "FORWARD"
+  view = "default"
+  soa_default_ttl = 3600
+}
+
+data "infoblox_zone_auth" "acctest" {
+  fqdn = "${infoblox_zone_auth.acctest.fqdn}"
+  view = "default"
+}`, fqdn)
+}
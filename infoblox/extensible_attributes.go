@@ -0,0 +1,110 @@
+package infoblox
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox/api/common"
+	"github.com/sky-uk/skyinfoblox/api/extensibleattributedef"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+	"log"
+	"net/http"
+	"sync"
+)
+
+// extAttrTypeCache - the types of the extensible attribute definitions of the grid, keyed by attribute name. They are
+// read once for the lifetime of the provider, and again when an attribute without a known definition is sent.
+type extAttrTypeCache struct {
+	sync.Mutex
+	types map[string]string
+}
+
+// defaultExtAttrs - returns the default extensible attributes of the provider
+func defaultExtAttrs(m interface{}) map[string]interface{} {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return nil
+	}
+	return meta.defaultExtAttrs
+}
+
+// mergedExtAttrs - returns the extattrs of the resource merged over the provider default_extattrs. Nil is returned
+// when there are none so attributes set outside Terraform are left alone, unless the resource has just removed its
+// last attribute, which an empty map clears on the grid.
+func mergedExtAttrs(d *schema.ResourceData, m interface{}) map[string]interface{} {
+	merged := util.MergeExtAttrs(defaultExtAttrs(m), d.Get("extattrs").(map[string]interface{}))
+	if merged == nil && d.HasChange("extattrs") {
+		return make(map[string]interface{})
+	}
+	return merged
+}
+
+// extAttrTypes - returns the types of the extensible attribute definitions of the grid, keyed by attribute name,
+// reading the definitions when some of the extensible attributes have none cached yet. Nil is returned when the
+// definitions can't be read, the values are then sent as strings.
+func extAttrTypes(m interface{}, extAttrs common.ExtensibleAttributes) map[string]string {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return nil
+	}
+	meta.extAttrTypes.Lock()
+	defer meta.extAttrTypes.Unlock()
+	cached := meta.extAttrTypes.types != nil
+	for name := range extAttrs {
+		if _, ok := meta.extAttrTypes.types[name]; !ok {
+			cached = false
+		}
+	}
+	if cached {
+		return meta.extAttrTypes.types
+	}
+
+	getAllAPI := extensibleattributedef.NewGetAll([]string{"name", "type"})
+	err := meta.client.Do(getAllAPI)
+	if err != nil {
+		log.Printf("[WARN] Could not read the extensible attribute definitions, sending the values as strings: %v", err)
+		return meta.extAttrTypes.types
+	}
+	if getAllAPI.StatusCode() != http.StatusOK {
+		log.Printf("[WARN] Could not read the extensible attribute definitions, sending the values as strings: Invalid HTTP response code %d returned - response %s",
+			getAllAPI.StatusCode(), string(getAllAPI.RawResponse()))
+		return meta.extAttrTypes.types
+	}
+	types := make(map[string]string)
+	for _, definition := range *getAllAPI.ResponseObject().(*[]extensibleattributedef.ExtensibleAttributeDef) {
+		types[definition.Name] = definition.Type
+	}
+	meta.extAttrTypes.types = types
+	return types
+}
+
+// forgetExtAttrTypes - drops the cached extensible attribute definitions, once one is changed or deleted
+func forgetExtAttrTypes(m interface{}) {
+	if meta, ok := m.(*providerMeta); ok {
+		meta.extAttrTypes.Lock()
+		defer meta.extAttrTypes.Unlock()
+		meta.extAttrTypes.types = nil
+	}
+}
+
+// convertExtAttrs - converts the extensible attributes sent to the grid to the types of their definitions
+func convertExtAttrs(m interface{}, extAttrs *common.ExtensibleAttributes) *common.ExtensibleAttributes {
+	if extAttrs != nil && len(*extAttrs) > 0 {
+		util.ConvertExtAttrValues(extAttrs, extAttrTypes(m, *extAttrs))
+	}
+	return extAttrs
+}
+
+// buildExtAttrs - builds the extensible attributes sent to the grid for a resource, including the provider defaults
+func buildExtAttrs(d *schema.ResourceData, m interface{}) *common.ExtensibleAttributes {
+	return convertExtAttrs(m, util.BuildExtAttrsFromT(mergedExtAttrs(d, m)))
+}
+
+// buildInheritableExtAttrs - same as buildExtAttrs, applying the extattrs_descendants_action of the resource
+func buildInheritableExtAttrs(d *schema.ResourceData, m interface{}) *common.ExtensibleAttributes {
+	return convertExtAttrs(m, util.BuildInheritableExtAttrsFromT(mergedExtAttrs(d, m), d.Get("extattrs_descendants_action").([]interface{})))
+}
+
+// flattenExtAttrs - builds the extattrs of a resource from the grid, leaving out the attributes added by the
+// provider default_extattrs so they don't show up as drift
+func flattenExtAttrs(d *schema.ResourceData, m interface{}, IBXExtAttrs *common.ExtensibleAttributes) map[string]interface{} {
+	return util.RemoveDefaultExtAttrs(util.BuildExtAttrsFromIBX(IBXExtAttrs), defaultExtAttrs(m), d.Get("extattrs").(map[string]interface{}))
+}
@@ -0,0 +1,62 @@
+package infoblox
+
+import (
+	"context"
+	"github.com/sky-uk/skyinfoblox/api/common"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/wapitest"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestExtAttrTypesCached(t *testing.T) {
+	fake := wapitest.NewServer()
+	defer fake.Close()
+	for name, extAttrType := range map[string]string{"Cost": "INTEGER", "Owner": "STRING"} {
+		if _, err := fake.Create("extensibleattributedef", map[string]interface{}{"name": name, "type": extAttrType}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var reads int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/extensibleattributedef") {
+			atomic.AddInt32(&reads, 1)
+		}
+		fake.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	meta, err := configureClient(testProviderResourceData(t, server.URL, nil), context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	convert := func(name, value string) interface{} {
+		extAttrs := convertExtAttrs(meta, &common.ExtensibleAttributes{name: {Value: value}})
+		return (*extAttrs)[name].Value
+	}
+
+	for i := 0; i < 3; i++ {
+		if value := convert("Cost", "10"); value != int64(10) {
+			t.Fatalf("Expected Cost to be sent as an integer, got %#v", value)
+		}
+	}
+	if atomic.LoadInt32(&reads) != 1 {
+		t.Fatalf("Expected the definitions to be read once, read %d times", reads)
+	}
+
+	// an attribute without a cached definition reads them again, e.g. one created since
+	if _, err := fake.Create("extensibleattributedef", map[string]interface{}{"name": "Rack", "type": "INTEGER"}); err != nil {
+		t.Fatal(err)
+	}
+	if value := convert("Rack", "7"); value != int64(7) || atomic.LoadInt32(&reads) != 2 {
+		t.Fatalf("Expected the definitions to be read again for Rack, got %#v after %d reads", value, reads)
+	}
+
+	forgetExtAttrTypes(meta)
+	convert("Owner", "ops")
+	if atomic.LoadInt32(&reads) != 3 {
+		t.Fatalf("Expected the definitions to be read again once forgotten, read %d times", reads)
+	}
+}
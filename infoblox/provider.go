@@ -1,14 +1,19 @@
 package infoblox
 
 import (
+	"context"
+	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+	"net/url"
+	"time"
 )
 
 // Provider : The infoblox terraform provider
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"username": &schema.Schema{
 				Type:        schema.TypeString,
@@ -41,30 +46,146 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("INFOBLOX_CLIENT_DEBUG", false),
 				Description: "infoblox client debug",
 			},
+			"ca_bundle": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INFOBLOX_CA_BUNDLE", nil),
+				Description: "PEM encoded CAs, or the path of a PEM file, trusted to verify the Infoblox appliance certificate instead of the system ones",
+			},
+			"client_certificate": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INFOBLOX_CLIENT_CERTIFICATE", nil),
+				Description: "PEM encoded client certificate, or the path of a PEM file, presented to the Infoblox appliance for mutual TLS",
+			},
+			"client_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("INFOBLOX_CLIENT_KEY", nil),
+				Description: "PEM encoded key of the client certificate, or the path of a PEM file",
+			},
+			"tls_server_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INFOBLOX_TLS_SERVER_NAME", nil),
+				Description: "Name the Infoblox appliance certificate is verified against, when it differs from the server host",
+			},
+			"proxy_url": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("INFOBLOX_PROXY_URL", nil),
+				Description:  "HTTP(S) proxy the requests to the Infoblox appliance go through",
+				ValidateFunc: util.ValidateProxyURL,
+			},
+			"max_idle_conns": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("INFOBLOX_MAX_IDLE_CONNS", 10),
+				Description:  "Maximum number of idle connections kept open to the Infoblox appliance for reuse",
+				ValidateFunc: util.ValidateUnsignedInteger,
+			},
+			"idle_conn_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("INFOBLOX_IDLE_CONN_TIMEOUT", 90),
+				Description:  "Seconds an idle connection is kept open before being closed",
+				ValidateFunc: util.ValidateUnsignedInteger,
+			},
+			"max_in_flight": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("INFOBLOX_MAX_IN_FLIGHT", 0),
+				Description:  "Maximum number of requests sent to the Infoblox appliance at the same time, 0 means no limit",
+				ValidateFunc: util.ValidateUnsignedInteger,
+			},
+			"wapi_version": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("INFOBLOX_WAPI_VERSION", skyinfoblox.DefaultWapiVersion),
+				Description:  "WAPI version the requests are sent to, e.g. 2.6.1. It must be supported by the grid",
+				ValidateFunc: util.ValidateWapiVersion,
+			},
+			"retry_max_attempts": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("INFOBLOX_RETRY_MAX_ATTEMPTS", 4),
+				Description:  "Maximum number of times a request failing with a network error or a 5xx response is sent, 1 disables the retries",
+				ValidateFunc: util.ValidateUnsignedInteger,
+			},
+			"retry_max_wait": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("INFOBLOX_RETRY_MAX_WAIT", 30),
+				Description:  "Maximum number of seconds to wait between two attempts of a request",
+				ValidateFunc: util.ValidateUnsignedInteger,
+			},
+			"request_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("INFOBLOX_REQUEST_TIMEOUT", 60),
+				Description:  "Maximum number of seconds an attempt of a request may take, 0 means no timeout",
+				ValidateFunc: util.ValidateUnsignedInteger,
+			},
+			"default_extattrs": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Extensible attributes set on every object the provider creates or updates, unless the resource sets them itself",
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"infoblox_arecord":      dataSourceARecord(),
+			"infoblox_cname_record": dataSourceCNAMERecord(),
+			"infoblox_txt_record":   dataSourceTXTRecord(),
+			"infoblox_srv_record":   dataSourceSRVRecord(),
+			"infoblox_network":      dataSourceNetwork(),
+			"infoblox_zone_auth":    dataSourceZoneAuth(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 
-			"infoblox_cname_record":        resourceCNAMERecord(),
-			"infoblox_arecord":             resourceARecord(),
-			"infoblox_srv_record":          resourceSRVRecord(),
-			"infoblox_txtrecord":           resourceTXTRecord(),
-			"infoblox_network":             resourceNetwork(),
-			"infoblox_zone_auth":           resourceZoneAuth(),
-			"infoblox_dhcp_range":          resourceDHCPRange(),
-			"infoblox_admin_user":          resourceAdminUser(),
-			"infoblox_admin_group":         resourceAdminGroup(),
-			"infoblox_admin_role":          resourceAdminRole(),
-			"infoblox_ns_record":           resourceNSRecord(),
-			"infoblox_zone_delegated":      resourceZoneDelegated(),
-			"infoblox_permission":          resourcePermission(),
-			"infoblox_zone_stub":           resourceZoneStub(),
-			"infoblox_zone_forward":        resourceZoneForward(),
-			"infoblox_ns_group_delegation": resourceNSGroupDelegation(),
+			"infoblox_cname_record":                    resourceCNAMERecord(),
+			"infoblox_arecord":                         resourceARecord(),
+			"infoblox_srv_record":                      resourceSRVRecord(),
+			"infoblox_txtrecord":                       resourceTXTRecord(),
+			"infoblox_network":                         resourceNetwork(),
+			"infoblox_zone_auth":                       resourceZoneAuth(),
+			"infoblox_dhcp_range":                      resourceDHCPRange(),
+			"infoblox_admin_user":                      resourceAdminUser(),
+			"infoblox_admin_group":                     resourceAdminGroup(),
+			"infoblox_admin_role":                      resourceAdminRole(),
+			"infoblox_ns_record":                       resourceNSRecord(),
+			"infoblox_zone_delegated":                  resourceZoneDelegated(),
+			"infoblox_permission":                      resourcePermission(),
+			"infoblox_zone_stub":                       resourceZoneStub(),
+			"infoblox_zone_forward":                    resourceZoneForward(),
+			"infoblox_ns_group_delegation":             resourceNSGroupDelegation(),
+			"infoblox_network_container":               resourceNetworkContainer(),
+			"infoblox_host_record":                     resourceHostRecord(),
+			"infoblox_ptr_record":                      resourcePTRRecord(),
+			"infoblox_aaaa_record":                     resourceAAAARecord(),
+			"infoblox_mx_record":                       resourceMXRecord(),
+			"infoblox_extensible_attribute_definition": resourceExtensibleAttributeDefinition(),
+			"infoblox_named_acl":                       resourceNamedACL(),
+			"infoblox_dns_view":                        resourceDNSView(),
+			"infoblox_network_view":                    resourceNetworkView(),
 		},
-		ConfigureFunc: providerConfigure,
 	}
+	gateOnWapiVersion(provider.ResourcesMap)
+	gateOnWapiVersion(provider.DataSourcesMap)
+	provider.ConfigureFunc = providerConfigure(provider)
+	return provider
 }
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+
+// providerConfigure - returns the function configuring the client of the provider. The requests of the
+// client are bound to the provider stop context so they are aborted when Terraform is interrupted.
+func providerConfigure(provider *schema.Provider) schema.ConfigureFunc {
+	return func(d *schema.ResourceData) (interface{}, error) {
+		return configureClient(d, provider.StopContext())
+	}
+}
+
+func configureClient(d *schema.ResourceData, stopContext context.Context) (interface{}, error) {
 
 	username := d.Get("username").(string)
 	password := d.Get("password").(string)
@@ -72,7 +193,39 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	ignoreSSL := d.Get("allow_unverified_ssl").(bool)
 	clientDebug := d.Get("client_debug").(bool)
 
-	ibxClient := skyinfoblox.NewInfobloxClient(server, username, password, ignoreSSL, clientDebug)
+	transportOptions := skyinfoblox.DefaultTransportOptions()
+	transportOptions.MaxIdleConns = d.Get("max_idle_conns").(int)
+	transportOptions.IdleConnTimeout = time.Duration(d.Get("idle_conn_timeout").(int)) * time.Second
+	transportOptions.MaxInFlight = d.Get("max_in_flight").(int)
+	tlsConfig, err := util.BuildTLSConfig(d.Get("ca_bundle").(string), d.Get("client_certificate").(string),
+		d.Get("client_key").(string), d.Get("tls_server_name").(string))
+	if err != nil {
+		return nil, err
+	}
+	transportOptions.TLSConfig = tlsConfig
+	if v, ok := d.GetOk("proxy_url"); ok {
+		proxyURL, err := url.Parse(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid proxy_url %s: %s", v.(string), err)
+		}
+		transportOptions.ProxyURL = proxyURL
+	}
+
+	ibxClient := skyinfoblox.NewInfobloxClientWithTransportOptions(server, username, password, ignoreSSL, clientDebug, transportOptions)
+	ibxClient.RetryPolicy.MaxAttempts = d.Get("retry_max_attempts").(int)
+	ibxClient.RetryPolicy.MaxWait = time.Duration(d.Get("retry_max_wait").(int)) * time.Second
+	ibxClient.RequestTimeout = time.Duration(d.Get("request_timeout").(int)) * time.Second
+	ibxClient.SetContext(stopContext)
+	ibxClient.WapiVersion = d.Get("wapi_version").(string)
+	registerClient(ibxClient)
+	capabilities, err := detectWapiCapabilities(ibxClient)
+	if err != nil {
+		return nil, err
+	}
 
-	return ibxClient, nil
+	return &providerMeta{
+		client:          ibxClient,
+		defaultExtAttrs: d.Get("default_extattrs").(map[string]interface{}),
+		capabilities:    capabilities,
+	}, nil
 }
@@ -1,10 +1,17 @@
 package infoblox
 
 import (
+	"context"
+	"flag"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/wapitest"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 )
 
 var testAccProviders map[string]terraform.ResourceProvider
@@ -17,6 +24,25 @@ func init() {
 	}
 }
 
+// TestMain - runs the acceptance tests against an in-process fake grid unless INFOBLOX_SERVER points at a real one
+func TestMain(m *testing.M) {
+	if os.Getenv("INFOBLOX_SERVER") != "" {
+		os.Exit(m.Run())
+	}
+	server := wapitest.NewServer()
+	// resource.Test refuses to run outside verbose mode, and TF_ACC set by the caller, even empty, wins
+	flag.Parse()
+	if _, ok := os.LookupEnv("TF_ACC"); !ok && testing.Verbose() {
+		os.Setenv("TF_ACC", "1")
+	}
+	os.Setenv("INFOBLOX_SERVER", server.URL)
+	os.Setenv("INFOBLOX_USERNAME", wapitest.Username)
+	os.Setenv("INFOBLOX_PASSWORD", wapitest.Password)
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -28,6 +54,64 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+// testProviderHungServer - returns a grid master which never answers, until the returned function closes it
+func testProviderHungServer() (*httptest.Server, func()) {
+	hang := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-hang:
+		case <-r.Context().Done():
+		}
+	}))
+	return server, func() {
+		close(hang)
+		server.Close()
+	}
+}
+
+func testProviderResourceData(t *testing.T, server string, settings map[string]interface{}) *schema.ResourceData {
+	raw := map[string]interface{}{
+		"server":             server,
+		"username":           wapitest.Username,
+		"password":           wapitest.Password,
+		"retry_max_attempts": 1,
+	}
+	for key, value := range settings {
+		raw[key] = value
+	}
+	return schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, raw)
+}
+
+func TestProviderRequestTimeout(t *testing.T) {
+	server, closeServer := testProviderHungServer()
+	defer closeServer()
+
+	start := time.Now()
+	_, err := configureClient(testProviderResourceData(t, server.URL, map[string]interface{}{"request_timeout": 1}), context.Background())
+	if err == nil || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Fatalf("Expected the request to time out, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("The request timed out after %s, request_timeout is 1 second", elapsed)
+	}
+}
+
+func TestProviderStopContext(t *testing.T) {
+	server, closeServer := testProviderHungServer()
+	defer closeServer()
+
+	stopContext, stop := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, stop)
+	start := time.Now()
+	_, err := configureClient(testProviderResourceData(t, server.URL, map[string]interface{}{"request_timeout": 0}), stopContext)
+	if err == nil || !strings.Contains(err.Error(), context.Canceled.Error()) {
+		t.Fatalf("Expected the request to be cancelled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("The request was cancelled after %s", elapsed)
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("INFOBLOX_USERNAME"); v == "" {
 		t.Fatal("INFOBLOX_USERNAME must be set for acceptance tests")
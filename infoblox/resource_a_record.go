@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/skyinfoblox/api"
 	"github.com/sky-uk/skyinfoblox/api/records"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
 	"log"
 	"net/http"
+	"strings"
 )
 
 func resourceARecord() *schema.Resource {
@@ -15,13 +18,19 @@ func resourceARecord() *schema.Resource {
 		Read:   resourceARecordRead,
 		Update: resourceARecordUpdate,
 		Delete: resourceARecordDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceARecordImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"address": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "IP address for hostname",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Description:   "IP address for hostname",
+				ConflictsWith: []string{"allocate_from"},
 			},
+			"allocate_from": allocateFromIPSchema([]string{"address"}),
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -47,12 +56,24 @@ func resourceARecord() *schema.Resource {
 				Computed:    true,
 				Description: "Internal Reference for the record",
 			},
+			"create_ptr": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Manage the matching PTR record in the reverse zone alongside the A record, a PTR record removed out of band is recreated by the next update",
+			},
+			"ptr_ref": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Internal Reference for the PTR record managed by create_ptr",
+			},
+			"extattrs": util.ExtAttrsSchema(),
 		},
 	}
 }
 
 func resourceARecordCreate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var name, address string
 	var ttl int
 	var createARecord records.ARecord
@@ -63,11 +84,18 @@ func resourceARecordCreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("name argument is required")
 	}
 
+	var nextAvailableIP *api.ObjectFunction
 	if v, ok := d.GetOk("address"); ok {
 		address = v.(string)
 		createARecord.IPv4 = address
+	} else if v, ok := d.GetOk("allocate_from"); ok {
+		var err error
+		nextAvailableIP, err = buildNextAvailableIP(v.([]interface{})[0].(map[string]interface{}))
+		if err != nil {
+			return err
+		}
 	} else {
-		return fmt.Errorf("address argument is required")
+		return fmt.Errorf("one of address or allocate_from is required")
 	}
 
 	if v, ok := d.GetOk("ttl"); ok {
@@ -81,43 +109,87 @@ func resourceARecordCreate(d *schema.ResourceData, m interface{}) error {
 	}
 	createARecord.UseTTL = &useTTL
 
+	createARecord.ExtAttrs = buildExtAttrs(d, m)
+
 	createAPI := records.NewCreateARecord(createARecord)
+	if nextAvailableIP != nil {
+		createAPI = records.NewCreateARecordNextAvailableIP(createARecord, nextAvailableIP)
+	}
 	createARecordErr := infobloxClient.Do(createAPI)
 	if createARecordErr != nil {
 		return createARecordErr
 	}
+	if skyinfoblox.IsConflict(createAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the A record already exists, import it rather than creating it - %s", createAPI.Error())
+	}
 	if createAPI.StatusCode() != http.StatusCreated {
 		return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %+v returned. Response object was %+v", createAPI.StatusCode(), createAPI.GetResponse())
 	}
 	response := createAPI.GetResponse()
 	d.SetId(response)
+
+	if d.Get("create_ptr").(bool) {
+		ptrRef, err := createARecordPTR(infobloxClient, d.Id())
+		if err != nil {
+			return err
+		}
+		d.Set("ptr_ref", ptrRef)
+	}
 	return resourceARecordRead(d, m)
 }
 
 func resourceARecordRead(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
-	fields := []string{"name", "ipv4addr", "use_ttl", "ttl", "zone"}
+	infobloxClient := m.(*providerMeta).client
+	fields := []string{"name", "ipv4addr", "view", "use_ttl", "ttl", "zone", "extattrs"}
 	getSingleARecordAPI := records.NewGetARecord(d.Id(), fields)
 	readErr := infobloxClient.Do(getSingleARecordAPI)
 	if readErr != nil {
+		return fmt.Errorf("Infoblox Read Error: %+v", readErr)
+	}
+	if skyinfoblox.IsNotFound(getSingleARecordAPI.Error()) {
 		d.SetId("")
-		return fmt.Errorf("Record does not exist")
+		return nil
 	}
 	if getSingleARecordAPI.StatusCode() != http.StatusOK {
-		return fmt.Errorf("Error reading A record : %s", getSingleARecordAPI.ResponseObject())
+		return fmt.Errorf("Error reading A record : %s", getSingleARecordAPI.Error())
 	}
 	readData := getSingleARecordAPI.GetResponse()
 	d.Set("name", readData.Name)
 	d.Set("zone", readData.Zone)
 	d.Set("address", readData.IPv4)
 	d.Set("ttl", readData.TTL)
+	d.Set("use_ttl", readData.UseTTL)
 	d.Set("ref", readData.Ref)
+	d.Set("extattrs", flattenExtAttrs(d, m, readData.ExtAttrs))
+
+	ptrRef := d.Get("ptr_ref").(string)
+	if ptrRef != "" {
+		getPTRAPI := records.NewGetPTRRecord(ptrRef, []string{"ptrdname"})
+		err := infobloxClient.Do(getPTRAPI)
+		if err != nil {
+			return fmt.Errorf("Infoblox Read Error: %+v", err)
+		}
+		if skyinfoblox.IsNotFound(getPTRAPI.Error()) {
+			// The PTR record was removed out of band, the next update recreates it while create_ptr is set
+			ptrRef = ""
+		} else if getPTRAPI.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %d returned - response %s", getPTRAPI.StatusCode(), string(getPTRAPI.RawResponse()))
+		}
+	}
+	if ptrRef == "" && d.Get("create_ptr").(bool) {
+		var err error
+		ptrRef, err = findARecordPTR(infobloxClient, readData)
+		if err != nil {
+			return fmt.Errorf("Infoblox Read Error: %+v", err)
+		}
+	}
+	d.Set("ptr_ref", ptrRef)
 
 	return nil
 }
 
 func resourceARecordUpdate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var recordReference string
 	var hasChanges bool
 	if v, ok := d.GetOk("ref"); ok {
@@ -153,27 +225,32 @@ func resourceARecordUpdate(d *schema.ResourceData, m interface{}) error {
 		recordToUpdate.IPv4 = newAddress.(string)
 	}
 
+	if d.HasChange("extattrs") {
+		hasChanges = true
+		recordToUpdate.ExtAttrs = buildExtAttrs(d, m)
+	}
+
 	if hasChanges {
 		updateAPI := records.NewUpdateARecord(recordReference, recordToUpdate)
 		changeErr := infobloxClient.Do(updateAPI)
 		if changeErr != nil {
-			log.Printf(fmt.Sprintf("[DEBUG] Error updating  A record: %s", changeErr))
+			log.Printf("[DEBUG] Error updating  A record: %s", changeErr)
 		}
 		if updateAPI.StatusCode() != http.StatusOK {
 			return fmt.Errorf("Error updating A Record : %s", updateAPI.ResponseObject())
 		}
 		d.SetId(updateAPI.GetResponse())
-		return resourceARecordRead(d, m)
-
 	}
 
-	return nil
-
+	if err := updateARecordPTR(d, infobloxClient); err != nil {
+		return err
+	}
+	return resourceARecordRead(d, m)
 }
 
 func resourceARecordDelete(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
-	deleteAPI := records.NewDelete(d.Id())
+	infobloxClient := m.(*providerMeta).client
+	deleteAPI := records.NewDeleteARecord(d.Id(), d.Get("create_ptr").(bool))
 	deleteRecordErr := infobloxClient.Do(deleteAPI)
 	if deleteRecordErr != nil {
 		return deleteRecordErr
@@ -184,3 +261,203 @@ func resourceARecordDelete(d *schema.ResourceData, m interface{}) error {
 	d.SetId("")
 	return nil
 }
+
+// resourceARecordImport - imports an A record by reference or name/view, taking over its existing PTR record
+// as if it had been created with create_ptr
+func resourceARecordImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	states, err := importStateFunc("record:a", "name", "view")(d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	infobloxClient := m.(*providerMeta).client
+	getAPI := records.NewGetARecord(d.Id(), []string{"name", "ipv4addr", "view"})
+	err = infobloxClient.Do(getAPI)
+	if err != nil {
+		return nil, fmt.Errorf("Infoblox Import Error: %+v", err)
+	}
+	if getAPI.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("Infoblox Import Error: Invalid HTTP response code %d returned - response %s", getAPI.StatusCode(), string(getAPI.RawResponse()))
+	}
+	ptrRef, err := findARecordPTR(infobloxClient, getAPI.GetResponse())
+	if err != nil {
+		return nil, fmt.Errorf("Infoblox Import Error: %+v", err)
+	}
+	if ptrRef != "" {
+		d.Set("create_ptr", true)
+		d.Set("ptr_ref", ptrRef)
+	}
+	return states, nil
+}
+
+// findARecordPTR - returns the reference of the PTR record matching the name and address of an A record in its view,
+// or an empty reference when there is none
+func findARecordPTR(infobloxClient *skyinfoblox.InfobloxClient, aRecord records.ARecord) (string, error) {
+	searchFields := map[string]string{"ipv4addr": aRecord.IPv4, "ptrdname": aRecord.Name, "_max_results": "1"}
+	if aRecord.View != "" {
+		searchFields["view"] = aRecord.View
+	}
+	ptrRecords := new([]records.PTRRecord)
+	searchAPI := api.NewSearch("record:ptr", searchFields, []string{"ptrdname"}, ptrRecords)
+	err := infobloxClient.Do(searchAPI)
+	if err != nil {
+		return "", err
+	}
+	if searchAPI.StatusCode() != http.StatusOK {
+		return "", fmt.Errorf("Invalid HTTP response code %d returned while searching the PTR record - response %s", searchAPI.StatusCode(), string(searchAPI.RawResponse()))
+	}
+	if len(*ptrRecords) == 0 {
+		return "", nil
+	}
+	return (*ptrRecords)[0].Ref, nil
+}
+
+// createARecordPTR - creates the PTR record matching the name, address and TTL of an A record and returns its reference
+func createARecordPTR(infobloxClient *skyinfoblox.InfobloxClient, recordReference string) (string, error) {
+	getAPI := records.NewGetARecord(recordReference, []string{"name", "ipv4addr", "view", "ttl", "use_ttl"})
+	err := infobloxClient.Do(getAPI)
+	if err != nil {
+		return "", fmt.Errorf("Infoblox Create Error: %+v", err)
+	}
+	if getAPI.StatusCode() != http.StatusOK {
+		return "", fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %d returned - response %s", getAPI.StatusCode(), string(getAPI.RawResponse()))
+	}
+	aRecord := getAPI.GetResponse()
+
+	createPTRRecord := records.PTRRecord{
+		IPv4:     aRecord.IPv4,
+		PtrdName: aRecord.Name,
+		View:     aRecord.View,
+		TTL:      aRecord.TTL,
+		UseTTL:   aRecord.UseTTL,
+	}
+	createAPI := records.NewCreatePTRRecord(createPTRRecord)
+	err = infobloxClient.Do(createAPI)
+	if err != nil {
+		return "", fmt.Errorf("Infoblox Create Error: %+v", err)
+	}
+	if skyinfoblox.IsConflict(createAPI.Error()) {
+		return "", fmt.Errorf("Infoblox Create Error: the PTR record of the A record already exists, delete it or set create_ptr to false - %s", createAPI.Error())
+	}
+	if createAPI.StatusCode() != http.StatusCreated {
+		return "", fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %d returned while creating the PTR record - response %s", createAPI.StatusCode(), string(createAPI.RawResponse()))
+	}
+	return createAPI.GetResponse(), nil
+}
+
+// updateARecordPTR - creates, updates or deletes the PTR record of an A record following changes to create_ptr,
+// the record name, address or TTL
+func updateARecordPTR(d *schema.ResourceData, infobloxClient *skyinfoblox.InfobloxClient) error {
+	createPTR := d.Get("create_ptr").(bool)
+	ptrRef := d.Get("ptr_ref").(string)
+
+	switch {
+	case createPTR && ptrRef == "":
+		newPTRRef, err := createARecordPTR(infobloxClient, d.Id())
+		if err != nil {
+			return err
+		}
+		d.Set("ptr_ref", newPTRRef)
+
+	case !createPTR && ptrRef != "":
+		deleteAPI := records.NewDelete(ptrRef)
+		err := infobloxClient.Do(deleteAPI)
+		if err != nil {
+			return fmt.Errorf("Infoblox Delete Error: %+v", err)
+		}
+		if deleteAPI.StatusCode() != http.StatusOK && deleteAPI.StatusCode() != http.StatusNotFound {
+			return fmt.Errorf("Infoblox Delete Error: Invalid HTTP response code %d returned while deleting the PTR record - response %s", deleteAPI.StatusCode(), string(deleteAPI.RawResponse()))
+		}
+		d.Set("ptr_ref", "")
+
+	case createPTR && (d.HasChange("name") || d.HasChange("address") || d.HasChange("ttl") || d.HasChange("use_ttl")):
+		useTTL := d.Get("use_ttl").(bool)
+		updatePTRRecord := records.PTRRecord{
+			IPv4:     d.Get("address").(string),
+			PtrdName: d.Get("name").(string),
+			TTL:      uint(d.Get("ttl").(int)),
+			UseTTL:   &useTTL,
+		}
+		updateAPI := records.NewUpdatePTRRecord(ptrRef, updatePTRRecord)
+		err := infobloxClient.Do(updateAPI)
+		if err != nil {
+			return fmt.Errorf("Infoblox Update Error: %+v", err)
+		}
+		if updateAPI.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Infoblox Update Error: Invalid HTTP response code %d returned while updating the PTR record - response %s", updateAPI.StatusCode(), string(updateAPI.RawResponse()))
+		}
+		d.Set("ptr_ref", updateAPI.GetResponse())
+	}
+	return nil
+}
+
+// buildNextAvailableIP - builds the func:nextavailableip object function for an allocate_from block
+func buildNextAvailableIP(allocateFrom map[string]interface{}) (*api.ObjectFunction, error) {
+	network := allocateFrom["network"].(string)
+	addressRange := allocateFrom["range"].(string)
+	objectParameters := map[string]string{
+		"network_view": allocateFrom["network_view"].(string),
+	}
+	var object string
+	switch {
+	case network != "" && addressRange != "":
+		return nil, fmt.Errorf("allocate_from takes either a network or a range, not both")
+	case network != "":
+		object = "network"
+		objectParameters["network"] = network
+	case addressRange != "":
+		object = "range"
+		addresses := strings.SplitN(addressRange, "-", 2)
+		objectParameters["start_addr"] = addresses[0]
+		objectParameters["end_addr"] = addresses[1]
+	default:
+		return nil, fmt.Errorf("allocate_from requires a network or a range")
+	}
+
+	var exclude []string
+	if v, ok := allocateFrom["exclude"].([]interface{}); ok {
+		for _, address := range v {
+			exclude = append(exclude, address.(string))
+		}
+	}
+	return api.NewNextAvailableIP(object, objectParameters, exclude), nil
+}
+
+// allocateFromIPSchema - returns the schema of an allocate_from block, letting the grid pick the next available IP
+func allocateFromIPSchema(conflictsWith []string) *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		ForceNew:      true,
+		MaxItems:      1,
+		Description:   "Lets the grid allocate the next available IP address of a network or range instead of setting the address",
+		ConflictsWith: conflictsWith,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"network": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The network to allocate the address from, in CIDR format",
+				},
+				"range": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "The range to allocate the address from, in start_address-end_address format",
+					ValidateFunc: util.ValidateAddressRange,
+				},
+				"network_view": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "default",
+					Description: "The network view of the network or range",
+				},
+				"exclude": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "IP addresses which must not be allocated",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
@@ -5,8 +5,9 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/skyinfoblox/api"
 	"github.com/sky-uk/skyinfoblox/api/records"
+	"net/http"
 	"testing"
 )
 
@@ -43,13 +44,143 @@ func TestAccResourceARecord(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "ttl", "900"),
 				),
 			},
+			{
+				Config:                  testAccResourceARecordUpdateTemplate(recordName),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"create_ptr"},
+			},
+			{
+				Config:                  testAccResourceARecordUpdateTemplate(recordName),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           recordName + "/default",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"create_ptr"},
+			},
+		},
+	})
+}
+
+func TestAccResourceARecordAllocateFrom(t *testing.T) {
+
+	randInt := acctest.RandInt()
+	recordName := fmt.Sprintf("a-record-allocated-%d.slupaas.bskyb.com", randInt)
+	networkAddr := fmt.Sprintf("10.2.%d.0/24", acctest.RandIntRange(0, 255))
+	resourceName := "infoblox_arecord.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccResourceARecordDestroy(state, recordName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceARecordAllocateFromTemplate(recordName, networkAddr),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceARecordExists(recordName, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", recordName),
+					resource.TestCheckResourceAttrSet(resourceName, "address"),
+					resource.TestCheckResourceAttr(resourceName, "allocate_from.0.network", networkAddr),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceARecordCreatePTR(t *testing.T) {
+
+	randInt := acctest.RandInt()
+	recordName := fmt.Sprintf("a-record-ptr-%d.slupaas.bskyb.com", randInt)
+	subnet := acctest.RandIntRange(0, 255)
+	resourceName := "infoblox_arecord.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccResourceARecordDestroy(state, recordName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceARecordCreatePTRTemplate(recordName, fmt.Sprintf("10.5.%d.10", subnet), true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceARecordExists(recordName, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "create_ptr", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "ptr_ref"),
+					testAccResourceARecordPTRMatches(resourceName),
+				),
+			},
+			{
+				// importing takes the existing PTR record over rather than creating a second one
+				Config:            testAccResourceARecordCreatePTRTemplate(recordName, fmt.Sprintf("10.5.%d.10", subnet), true),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     recordName + "/default",
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					if err := testAccResourceARecordDeletePTR(recordName); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccResourceARecordCreatePTRTemplate(recordName, fmt.Sprintf("10.5.%d.10", subnet), true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "create_ptr", "true"),
+					resource.TestCheckResourceAttr(resourceName, "ptr_ref", ""),
+				),
+			},
+			{
+				Config: testAccResourceARecordCreatePTRTemplate(recordName, fmt.Sprintf("10.5.%d.11", subnet), true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "address", fmt.Sprintf("10.5.%d.11", subnet)),
+					testAccResourceARecordPTRMatches(resourceName),
+				),
+			},
+			{
+				Config: testAccResourceARecordCreatePTRTemplate(recordName, fmt.Sprintf("10.5.%d.11", subnet), false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "create_ptr", "false"),
+					resource.TestCheckResourceAttr(resourceName, "ptr_ref", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceARecordDefaultExtAttrs(t *testing.T) {
+
+	randInt := acctest.RandInt()
+	recordName := fmt.Sprintf("a-record-extattrs-%d.slupaas.bskyb.com", randInt)
+	resourceName := "infoblox_arecord.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccResourceARecordDestroy(state, recordName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceARecordDefaultExtAttrsTemplate(recordName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceARecordExists(recordName, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "extattrs.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "extattrs.Site", "London"),
+					testAccResourceARecordExtAttr(resourceName, "ManagedBy", "terraform"),
+					testAccResourceARecordExtAttr(resourceName, "Site", "London"),
+				),
+			},
 		},
 	})
 }
 
 func testAccResourceARecordDestroy(state *terraform.State, recordName string) error {
 
-	infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "infoblox_arecord" {
@@ -83,7 +214,7 @@ func testAccResourceARecordExists(recordName, resourceName string) resource.Test
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("\nInfoblox A record resource %s ID not set", resourceName)
 		}
-		infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
 		fields := []string{"name", "ipv4addr", "ttl"}
 		getAllARec := records.NewGetAllARecords(fields)
 		err := infobloxClient.Do(getAllARec)
@@ -118,3 +249,110 @@ func testAccResourceARecordUpdateTemplate(arecordName string) string {
 	ttl = 900
 	}`, arecordName)
 }
+
+func testAccResourceARecordAllocateFromTemplate(arecordName, networkAddr string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_network" "acctest" {
+	network = "%s"
+	}
+
+	resource "infoblox_arecord" "acctest"{
+	name = "%s"
+	ttl = 900
+	allocate_from {
+		network = "${infoblox_network.acctest.network}"
+		exclude = ["${cidrhost(infoblox_network.acctest.network, 1)}"]
+	}
+	}`, networkAddr, arecordName)
+}
+
+func testAccResourceARecordPTRMatches(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("\nInfoblox A record resource %s not found in resources", resourceName)
+		}
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
+		api := records.NewGetPTRRecord(rs.Primary.Attributes["ptr_ref"], []string{"ipv4addr", "ptrdname"})
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Could not find the PTR record %s", rs.Primary.Attributes["ptr_ref"])
+		}
+		ptr := api.GetResponse()
+		if ptr.IPv4 != rs.Primary.Attributes["address"] || ptr.PtrdName != rs.Primary.Attributes["name"] {
+			return fmt.Errorf("PTR record %s/%s does not match the A record %s/%s", ptr.IPv4, ptr.PtrdName,
+				rs.Primary.Attributes["address"], rs.Primary.Attributes["name"])
+		}
+		return nil
+	}
+}
+
+func testAccResourceARecordDeletePTR(recordName string) error {
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
+	ptrRecords := new([]records.PTRRecord)
+	searchAPI := api.NewSearch("record:ptr", map[string]string{"ptrdname": recordName}, nil, ptrRecords)
+	if err := infobloxClient.Do(searchAPI); err != nil {
+		return err
+	}
+	if len(*ptrRecords) != 1 {
+		return fmt.Errorf("Expected a single PTR record for %s, found %d", recordName, len(*ptrRecords))
+	}
+	deleteAPI := records.NewDelete((*ptrRecords)[0].Ref)
+	if err := infobloxClient.Do(deleteAPI); err != nil {
+		return err
+	}
+	if deleteAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Could not delete the PTR record of %s: %s", recordName, string(deleteAPI.RawResponse()))
+	}
+	return nil
+}
+
+func testAccResourceARecordCreatePTRTemplate(arecordName, address string, createPTR bool) string {
+	return fmt.Sprintf(`
+	resource "infoblox_arecord" "acctest"{
+	name = "%s"
+	address = "%s"
+	create_ptr = %t
+	}`, arecordName, address, createPTR)
+}
+
+func testAccResourceARecordExtAttr(resourceName, name, value string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("\nInfoblox A record resource %s not found in resources", resourceName)
+		}
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
+		api := records.NewGetARecord(rs.Primary.ID, []string{"extattrs"})
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		extAttrs := api.GetResponse().ExtAttrs
+		if extAttrs == nil || (*extAttrs)[name].Value != value {
+			return fmt.Errorf("A record %s does not have the extensible attribute %s set to %s", rs.Primary.ID, name, value)
+		}
+		return nil
+	}
+}
+
+func testAccResourceARecordDefaultExtAttrsTemplate(arecordName string) string {
+	return fmt.Sprintf(`
+	provider "infoblox" {
+	default_extattrs {
+		ManagedBy = "terraform"
+		Site = "Reading"
+	}
+	}
+
+	resource "infoblox_arecord" "acctest"{
+	name = "%s"
+	address = "10.0.0.12"
+	extattrs {
+		Site = "London"
+	}
+	}`, arecordName)
+}
@@ -0,0 +1,195 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/skyinfoblox/api/records"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+	"net/http"
+)
+
+func resourceAAAARecord() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAAAARecordCreate,
+		Read:   resourceAAAARecordRead,
+		Update: resourceAAAARecordUpdate,
+		Delete: resourceAAAARecordDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("record:aaaa", "name", "view"),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "name for the AAAA record",
+				ValidateFunc: util.CheckLeadingTrailingSpaces,
+			},
+			"address": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "IPv6 address for the AAAA record",
+				ValidateFunc:     util.ValidateIPv6Address,
+				DiffSuppressFunc: util.SuppressEquivalentIPAddresses,
+			},
+			"view": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The DNS view in which the record resides",
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "DNS Zone for the record",
+			},
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "TTL in seconds for the record",
+				ValidateFunc: util.ValidateUnsignedInteger,
+			},
+			"use_ttl": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ref": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Internal Reference for the record",
+			},
+			"extattrs": util.ExtAttrsSchema(),
+		},
+	}
+}
+
+func resourceAAAARecordCreate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	var createAAAARecord records.AAAARecord
+
+	createAAAARecord.Name = d.Get("name").(string)
+	createAAAARecord.IPv6 = d.Get("address").(string)
+	if v, ok := d.GetOk("view"); ok {
+		createAAAARecord.View = v.(string)
+	}
+	if v, ok := d.GetOk("ttl"); ok {
+		createAAAARecord.TTL = uint(v.(int))
+	}
+	useTTL := d.Get("use_ttl").(bool)
+	createAAAARecord.UseTTL = &useTTL
+	if v, ok := d.GetOk("comment"); ok {
+		comment := v.(string)
+		createAAAARecord.Comment = &comment
+	}
+	createAAAARecord.ExtAttrs = buildExtAttrs(d, m)
+
+	createAPI := records.NewCreateAAAARecord(createAAAARecord)
+	err := infobloxClient.Do(createAPI)
+	if err != nil {
+		return fmt.Errorf("Infoblox Create Error: %+v", err)
+	}
+	if skyinfoblox.IsConflict(createAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the AAAA record already exists, import it rather than creating it - %s", createAPI.Error())
+	}
+	if createAPI.StatusCode() != http.StatusCreated {
+		return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %d returned - response %s", createAPI.StatusCode(), createAPI.GetResponse())
+	}
+	d.SetId(createAPI.GetResponse())
+	return resourceAAAARecordRead(d, m)
+}
+
+func resourceAAAARecordRead(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	fields := []string{"name", "ipv6addr", "view", "zone", "ttl", "use_ttl", "comment", "extattrs"}
+	getAPI := records.NewGetAAAARecord(d.Id(), fields)
+	err := infobloxClient.Do(getAPI)
+	if err != nil {
+		return fmt.Errorf("Infoblox Read Error: %+v", err)
+	}
+	if skyinfoblox.IsNotFound(getAPI.Error()) {
+		d.SetId("")
+		return nil
+	}
+	if getAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %d returned - response %s", getAPI.StatusCode(), string(getAPI.RawResponse()))
+	}
+
+	record := getAPI.GetResponse()
+	d.SetId(record.Ref)
+	d.Set("name", record.Name)
+	d.Set("address", record.IPv6)
+	d.Set("view", record.View)
+	d.Set("zone", record.Zone)
+	d.Set("ttl", record.TTL)
+	d.Set("use_ttl", record.UseTTL)
+	d.Set("comment", record.Comment)
+	d.Set("ref", record.Ref)
+	d.Set("extattrs", flattenExtAttrs(d, m, record.ExtAttrs))
+	return nil
+}
+
+func resourceAAAARecordUpdate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	var updateAAAARecord records.AAAARecord
+	hasChanges := false
+
+	if d.HasChange("name") {
+		updateAAAARecord.Name = d.Get("name").(string)
+		hasChanges = true
+	}
+	if d.HasChange("address") {
+		updateAAAARecord.IPv6 = d.Get("address").(string)
+		hasChanges = true
+	}
+	if d.HasChange("ttl") {
+		updateAAAARecord.TTL = uint(d.Get("ttl").(int))
+		hasChanges = true
+	}
+	if d.HasChange("use_ttl") {
+		useTTL := d.Get("use_ttl").(bool)
+		updateAAAARecord.UseTTL = &useTTL
+		hasChanges = true
+	}
+	if d.HasChange("comment") {
+		comment := d.Get("comment").(string)
+		updateAAAARecord.Comment = &comment
+		hasChanges = true
+	}
+	if d.HasChange("extattrs") {
+		updateAAAARecord.ExtAttrs = buildExtAttrs(d, m)
+		hasChanges = true
+	}
+
+	if hasChanges {
+		updateAPI := records.NewUpdateAAAARecord(d.Id(), updateAAAARecord)
+		err := infobloxClient.Do(updateAPI)
+		if err != nil {
+			return fmt.Errorf("Infoblox Update Error: %+v", err)
+		}
+		if updateAPI.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Infoblox Update Error: Invalid HTTP response code %d returned - response %s", updateAPI.StatusCode(), updateAPI.GetResponse())
+		}
+		d.SetId(updateAPI.GetResponse())
+	}
+	return resourceAAAARecordRead(d, m)
+}
+
+func resourceAAAARecordDelete(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	deleteAPI := records.NewDelete(d.Id())
+	err := infobloxClient.Do(deleteAPI)
+	if err != nil {
+		return fmt.Errorf("Infoblox Delete Error: %+v", err)
+	}
+	if deleteAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Delete Error: Invalid HTTP response code %d returned - response %s", deleteAPI.StatusCode(), deleteAPI.GetResponse())
+	}
+	d.SetId("")
+	return nil
+}
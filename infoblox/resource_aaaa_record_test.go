@@ -0,0 +1,118 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/sky-uk/skyinfoblox/api/records"
+	"net/http"
+	"testing"
+)
+
+func TestAccResourceAAAARecord(t *testing.T) {
+
+	randInt := acctest.RandInt()
+	recordName := fmt.Sprintf("aaaa-record-test-%d.slupaas.bskyb.com", randInt)
+	resourceName := "infoblox_aaaa_record.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceAAAARecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceAAAARecordCreateTemplate(recordName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceAAAARecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", recordName),
+					resource.TestCheckResourceAttr(resourceName, "address", "2001:db8::10"),
+					resource.TestCheckResourceAttr(resourceName, "ttl", "900"),
+				),
+			},
+			{
+				Config: testAccResourceAAAARecordUpdateTemplate(recordName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceAAAARecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", recordName),
+					resource.TestCheckResourceAttr(resourceName, "address", "2001:db8::11"),
+					resource.TestCheckResourceAttr(resourceName, "comment", "an updated AAAA record"),
+				),
+			},
+			{
+				Config:            testAccResourceAAAARecordUpdateTemplate(recordName),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccResourceAAAARecordCreateTemplate(recordName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceAAAARecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "comment", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceAAAARecordDestroy(state *terraform.State) error {
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "infoblox_aaaa_record" {
+			continue
+		}
+		api := records.NewGetAAAARecord(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusNotFound {
+			return fmt.Errorf("AAAA record %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccResourceAAAARecordExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("\nInfoblox AAAA record resource %s not found in resources", resourceName)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("\nInfoblox AAAA record resource %s ID not set", resourceName)
+		}
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
+		api := records.NewGetAAAARecord(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Could not find %s", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccResourceAAAARecordCreateTemplate(recordName string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_aaaa_record" "acctest" {
+	name = "%s"
+	address = "2001:db8::10"
+	ttl = 900
+	use_ttl = true
+	}`, recordName)
+}
+
+func testAccResourceAAAARecordUpdateTemplate(recordName string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_aaaa_record" "acctest" {
+	name = "%s"
+	address = "2001:db8::11"
+	ttl = 900
+	use_ttl = true
+	comment = "an updated AAAA record"
+	}`, recordName)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/admingroup"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
 	"net/http"
 )
 
@@ -14,6 +15,9 @@ func resourceAdminGroup() *schema.Resource {
 		Read:   resourceAdminGroupRead,
 		Update: resourceAdminGroupUpdate,
 		Delete: resourceAdminGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("admingroup", "name"),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -58,6 +62,7 @@ func resourceAdminGroup() *schema.Resource {
 				Optional:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"extattrs": util.ExtAttrsSchema(),
 		},
 	}
 }
@@ -83,7 +88,7 @@ func adminGroupBuildEmailAddressArray(emailAddresses *schema.Set) []string {
 func resourceAdminGroupCreate(d *schema.ResourceData, m interface{}) error {
 
 	var adminGroupObject admingroup.IBXAdminGroup
-	client := m.(*skyinfoblox.InfobloxClient)
+	client := m.(*providerMeta).client
 
 	if v, ok := d.GetOk("name"); ok && v != "" {
 		adminGroupObject.Name = v.(string)
@@ -108,6 +113,7 @@ func resourceAdminGroupCreate(d *schema.ResourceData, m interface{}) error {
 	if v, ok := d.GetOk("roles"); ok && v != nil {
 		adminGroupObject.Roles = adminGroupBuildStringArray(v)
 	}
+	adminGroupObject.ExtAttrs = buildExtAttrs(d, m)
 
 	createAPI := admingroup.NewCreate(adminGroupObject)
 	err := client.Do(createAPI)
@@ -123,14 +129,14 @@ func resourceAdminGroupCreate(d *schema.ResourceData, m interface{}) error {
 
 func resourceAdminGroupRead(d *schema.ResourceData, m interface{}) error {
 
-	returnFields := []string{"name", "comment", "disable", "roles", "email_addresses", "superuser", "access_method"}
+	returnFields := []string{"name", "comment", "disable", "roles", "email_addresses", "superuser", "access_method", "extattrs"}
 	reference := d.Id()
-	client := m.(*skyinfoblox.InfobloxClient)
+	client := m.(*providerMeta).client
 
 	getAdminGroupAPI := admingroup.NewGet(reference, returnFields)
 	err := client.Do(getAdminGroupAPI)
 	httpStatus := getAdminGroupAPI.StatusCode()
-	if httpStatus == http.StatusNotFound {
+	if skyinfoblox.IsNotFound(getAdminGroupAPI.Error()) {
 		d.SetId("")
 		return nil
 	}
@@ -148,6 +154,7 @@ func resourceAdminGroupRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("access_method", response.AccessMethod)
 	d.Set("email_addresses", response.EmailAddresses)
 	d.Set("roles", response.Roles)
+	d.Set("extattrs", flattenExtAttrs(d, m, response.ExtAttrs))
 
 	return nil
 }
@@ -197,11 +204,15 @@ func resourceAdminGroupUpdate(d *schema.ResourceData, m interface{}) error {
 		}
 		hasChanges = true
 	}
+	if d.HasChange("extattrs") {
+		adminGroupObject.ExtAttrs = buildExtAttrs(d, m)
+		hasChanges = true
+	}
 
 	if hasChanges {
 
 		returnFields := []string{"name", "comment", "disable", "roles", "email_addresses", "superuser", "access_method"}
-		client := m.(*skyinfoblox.InfobloxClient)
+		client := m.(*providerMeta).client
 		adminGroupObject.Reference = d.Id()
 
 		updateAdminGroupAPI := admingroup.NewUpdate(adminGroupObject, returnFields)
@@ -228,7 +239,7 @@ func resourceAdminGroupUpdate(d *schema.ResourceData, m interface{}) error {
 
 func resourceAdminGroupDelete(d *schema.ResourceData, m interface{}) error {
 
-	client := m.(*skyinfoblox.InfobloxClient)
+	client := m.(*providerMeta).client
 	reference := d.Id()
 
 	deleteAdminGroupAPI := admingroup.NewDelete(reference)
@@ -5,7 +5,6 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/admingroup"
 	"regexp"
 	"testing"
@@ -99,7 +98,7 @@ func testAccInfobloxAdminGroupCheckValueInKeyPattern(adminGroupResource string,
 
 func testAccInfobloxAdminGroupCheckDestroy(state *terraform.State, adminGroupName string) error {
 
-	client := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	client := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "infoblox_admin_group" {
@@ -133,7 +132,7 @@ func testAccInfobloxAdminGroupCheckExists(adminGroupName, adminGroupResource str
 			return fmt.Errorf("\nInfoblox Admin Group ID not set for %s in resources", adminGroupName)
 		}
 
-		client := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		client := testAccProvider.Meta().(*providerMeta).client
 		api := admingroup.NewGetAll()
 		err := client.Do(api)
 		if err != nil {
@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/adminrole"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
 	"net/http"
 )
 
@@ -14,6 +15,9 @@ func resourceAdminRole() *schema.Resource {
 		Read:   resourceAdminRoleRead,
 		Update: resourceAdminRoleUpdate,
 		Delete: resourceAdminRoleDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("adminrole", "name"),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -31,6 +35,7 @@ func resourceAdminRole() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"extattrs": util.ExtAttrsSchema(),
 		},
 	}
 }
@@ -38,7 +43,7 @@ func resourceAdminRole() *schema.Resource {
 func resourceAdminRoleCreate(d *schema.ResourceData, m interface{}) error {
 	var adminRoleObject adminrole.AdminRole
 
-	client := m.(*skyinfoblox.InfobloxClient)
+	client := m.(*providerMeta).client
 
 	if v, ok := d.GetOk("name"); ok && v != "" {
 		adminRoleObject.Name = v.(string)
@@ -50,6 +55,7 @@ func resourceAdminRoleCreate(d *schema.ResourceData, m interface{}) error {
 		disable := v.(bool)
 		adminRoleObject.Disable = &disable
 	}
+	adminRoleObject.ExtAttrs = buildExtAttrs(d, m)
 
 	createAPI := adminrole.NewCreate(adminRoleObject)
 	err := client.Do(createAPI)
@@ -70,12 +76,12 @@ func resourceAdminRoleCreate(d *schema.ResourceData, m interface{}) error {
 
 func resourceAdminRoleRead(d *schema.ResourceData, m interface{}) error {
 	reference := d.Id()
-	client := m.(*skyinfoblox.InfobloxClient)
+	client := m.(*providerMeta).client
 
 	getAdminRoleAPI := adminrole.NewGet(reference)
 	err := client.Do(getAdminRoleAPI)
 	httpStatus := getAdminRoleAPI.StatusCode()
-	if httpStatus == http.StatusNotFound {
+	if skyinfoblox.IsNotFound(getAdminRoleAPI.Error()) {
 		d.SetId("")
 		return nil
 	}
@@ -84,7 +90,7 @@ func resourceAdminRoleRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if httpStatus < http.StatusOK || httpStatus >= http.StatusBadRequest {
-		return fmt.Errorf("Infoblox Admin Role Create for %s failed with status code %d - %s", d.Id(), httpStatus, *getAdminRoleAPI.ResponseObject().(*string))
+		return fmt.Errorf("Infoblox Admin Role Read for %s failed with status code %d - %s", d.Id(), httpStatus, getAdminRoleAPI.Error())
 	}
 
 	response := *getAdminRoleAPI.ResponseObject().(*adminrole.AdminRole)
@@ -93,6 +99,7 @@ func resourceAdminRoleRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("name", response.Name)
 	d.Set("comment", response.Comment)
 	d.Set("disable", *response.Disable)
+	d.Set("extattrs", flattenExtAttrs(d, m, response.ExtAttrs))
 
 	return nil
 }
@@ -118,9 +125,13 @@ func resourceAdminRoleUpdate(d *schema.ResourceData, m interface{}) error {
 		adminRoleObject.Disable = &disable
 		hasChanges = true
 	}
+	if d.HasChange("extattrs") {
+		adminRoleObject.ExtAttrs = buildExtAttrs(d, m)
+		hasChanges = true
+	}
 
 	if hasChanges {
-		client := m.(*skyinfoblox.InfobloxClient)
+		client := m.(*providerMeta).client
 		adminRoleObject.Reference = d.Id()
 
 		updateAdminRoleAPI := adminrole.NewUpdate(adminRoleObject.Reference, adminRoleObject)
@@ -146,7 +157,7 @@ func resourceAdminRoleUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceAdminRoleDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*skyinfoblox.InfobloxClient)
+	client := m.(*providerMeta).client
 	reference := d.Id()
 
 	deleteAdminRoleAPI := adminrole.NewDelete(reference)
@@ -5,7 +5,6 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/adminrole"
 	"regexp"
 	"testing"
@@ -55,7 +54,7 @@ func TestAccInfobloxAdminRoleBasic(t *testing.T) {
 
 func testAccInfobloxAdminRoleCheckDestroy(state *terraform.State, adminRoleName string) error {
 
-	client := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	client := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "infoblox_admin_role" {
@@ -89,7 +88,7 @@ func testAccInfobloxAdminRoleCheckExists(adminRoleName, adminRoleResource string
 			return fmt.Errorf("\nInfoblox Admin Role ID not set for %s in resources", adminRoleName)
 		}
 
-		client := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		client := testAccProvider.Meta().(*providerMeta).client
 		api := adminrole.NewGetAll()
 		err := client.Do(api)
 		if err != nil {
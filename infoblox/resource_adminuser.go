@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/adminuser"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
 	"log"
 	"net/http"
 )
@@ -15,6 +16,9 @@ func resourceAdminUser() *schema.Resource {
 		Read:   resourceAdminUserRead,
 		Update: resourceAdminUserUpdate,
 		Delete: resourceAdminUserDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("adminuser", "name"),
+		},
 		Schema: map[string]*schema.Schema{
 			"ref": {
 				Type:        schema.TypeString,
@@ -48,16 +52,18 @@ func resourceAdminUser() *schema.Resource {
 				Description: "a comment on the user",
 			},
 			"password": {
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Password for the user, never read back from the grid",
 			},
+			"extattrs": util.ExtAttrsSchema(),
 		},
 	}
 }
 
 func resourceAdminUserCreate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var userCreate adminuser.AdminUser
 
 	if v, ok := d.GetOk("name"); ok {
@@ -84,12 +90,16 @@ func resourceAdminUserCreate(d *schema.ResourceData, m interface{}) error {
 	if v, ok := d.GetOk("password"); ok {
 		userCreate.Password = v.(string)
 	}
+	userCreate.ExtAttrs = buildExtAttrs(d, m)
 
 	userCreateAPI := adminuser.NewCreateAdminUser(userCreate)
 	createErr := infobloxClient.Do(userCreateAPI)
 	if createErr != nil {
 		return fmt.Errorf("error creating resource %s", createErr.Error())
 	}
+	if skyinfoblox.IsConflict(userCreateAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the admin user already exists, import it rather than creating it - %s", userCreateAPI.Error())
+	}
 	if userCreateAPI.StatusCode() != http.StatusCreated {
 		return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %+v returned. Response object was %+v", userCreateAPI.StatusCode(), *userCreateAPI.ResponseObject().(*string))
 	}
@@ -99,35 +109,39 @@ func resourceAdminUserCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceAdminUserRead(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var userRead adminuser.AdminUser
-	fieldList := []string{"name", "email", "comment", "admin_groups", "disable"}
+	fieldList := []string{"name", "email", "comment", "admin_groups", "disable", "extattrs"}
 	readAPI := adminuser.NewGetAdminUser(d.Id(), fieldList)
 	readErr := infobloxClient.Do(readAPI)
 	if readErr != nil {
 		return fmt.Errorf("error reading resource %s", readErr.Error())
 	}
-	if readAPI.StatusCode() == http.StatusNotFound {
+	if skyinfoblox.IsNotFound(readAPI.Error()) {
 		d.SetId("")
 		return nil
 	}
 
 	if readAPI.StatusCode() != http.StatusOK {
-		return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %+v returned. Response object was %+v", readAPI.StatusCode(), readAPI.ResponseObject().(string))
+		return fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %+v returned. Response object was %+v", readAPI.StatusCode(), readAPI.Error())
 	}
 
 	userRead = *readAPI.ResponseObject().(*adminuser.AdminUser)
 
+	d.Set("ref", userRead.Ref)
 	d.Set("name", userRead.Name)
-	d.Set("admin_groups", userRead.Groups)
+	if len(userRead.Groups) > 0 {
+		d.Set("admin_groups", userRead.Groups[0])
+	}
 	d.Set("email", userRead.Email)
 	d.Set("disable", userRead.Disable)
 	d.Set("comment", userRead.Comment)
+	d.Set("extattrs", flattenExtAttrs(d, m, userRead.ExtAttrs))
 	return nil
 }
 
 func resourceAdminUserUpdate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var updateUser adminuser.AdminUser
 	var readErr error
 	var hasChanges bool
@@ -160,6 +174,10 @@ func resourceAdminUserUpdate(d *schema.ResourceData, m interface{}) error {
 		_, newComment := d.GetChange("comment")
 		updateUser.Comment = newComment.(string)
 	}
+	if d.HasChange("extattrs") {
+		hasChanges = true
+		updateUser.ExtAttrs = buildExtAttrs(d, m)
+	}
 
 	if d.HasChange("password") {
 		hasChanges = true
@@ -186,7 +204,7 @@ func resourceAdminUserUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceAdminUserDelete(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	deleteAPI := adminuser.NewDeleteAdminUser(d.Id())
 	deleteErr := infobloxClient.Do(deleteAPI)
 	if deleteErr != nil {
@@ -204,7 +222,7 @@ func resourceAdminUserDelete(d *schema.ResourceData, m interface{}) error {
 }
 
 func doReadCall(userRef string, m interface{}) (adminuser.AdminUser, error) {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var userRead adminuser.AdminUser
 	fieldList := []string{"name", "email", "comment", "admin_groups", "disable"}
 	readAPI := adminuser.NewGetAdminUser(userRef, fieldList)
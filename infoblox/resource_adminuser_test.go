@@ -5,7 +5,6 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/adminuser"
 	"testing"
 )
@@ -38,6 +37,13 @@ func TestAccAdminUserResource(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "email", "user@domain.internal.com"),
 					resource.TestCheckResourceAttr(resourceName, "admin_groups", "APP-OVP-INFOBLOX-READONLY"),
 				),
+			}, {
+				Config:                  testAccResourceAdminUserNameUpdateTemplate(recordUserName),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           recordUserName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
 			},
 		},
 	})
@@ -45,7 +51,7 @@ func TestAccAdminUserResource(t *testing.T) {
 }
 
 func testAccResourceAdminUserDestroy(state *terraform.State) error {
-	infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "infoblox_admin_user" {
 			continue
@@ -78,7 +84,7 @@ func testAccResourceAdminUserExists(recordUserName, resourceName string) resourc
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("\nInfoblox Admin Userresource %s ID not set", resourceName)
 		}
-		infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
 		fields := []string{"name", "comment", "email", "admin_groups"}
 		getAdminUser := adminuser.NewGetAdminUser(rs.Primary.Attributes["id"], fields)
 		err := infobloxClient.Do(getAdminUser)
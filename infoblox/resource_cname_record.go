@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/records"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
 	"strings"
 )
 
@@ -14,6 +15,9 @@ func resourceCNAMERecord() *schema.Resource {
 		Read:   resourceCNAMERead,
 		Update: resourceCNAMEUpdate,
 		Delete: resourceCNAMEDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("record:cname", "name", "view"),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -54,6 +58,7 @@ func resourceCNAMERecord() *schema.Resource {
 				Required:    true,
 				Description: "Canonical name in FQDN format",
 			},
+			"extattrs": util.ExtAttrsSchema(),
 		},
 	}
 }
@@ -68,7 +73,7 @@ func validateUnsignedInteger(v interface{}, k string) (ws []string, errors []err
 
 func resourceCNAMECreate(d *schema.ResourceData, m interface{}) error {
 
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var cnameRecord records.GenericRecord
 	recordType := "cname"
 
@@ -97,6 +102,7 @@ func resourceCNAMECreate(d *schema.ResourceData, m interface{}) error {
 	if v, ok := d.GetOk("canonical"); ok {
 		cnameRecord.Canonical = v.(string)
 	}
+	cnameRecord.ExtAttrs = buildExtAttrs(d, m)
 
 	createAPI := records.NewCreateRecord(recordType, cnameRecord)
 
@@ -105,6 +111,9 @@ func resourceCNAMECreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Infoblox Create Error: %+v", err)
 	}
 
+	if skyinfoblox.IsConflict(createAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the CNAME record already exists, import it rather than creating it - %s", createAPI.Error())
+	}
 	if createAPI.StatusCode() != 201 {
 		return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %+v returned. Response object was %+v", createAPI.StatusCode(), createAPI.GetResponse())
 	}
@@ -116,9 +125,9 @@ func resourceCNAMECreate(d *schema.ResourceData, m interface{}) error {
 
 func resourceCNAMERead(d *schema.ResourceData, m interface{}) error {
 
-	returnFields := []string{"name", "comment", "view", "use_ttl", "ttl", "canonical"}
+	returnFields := []string{"name", "comment", "view", "use_ttl", "ttl", "canonical", "extattrs"}
 
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	resourceReference := d.Id()
 	getSingleCNAMEAPI := records.NewGetCNAMERecord(resourceReference, returnFields)
 
@@ -126,10 +135,13 @@ func resourceCNAMERead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return fmt.Errorf("Infoblox Read Error: %+v", err)
 	}
-	if getSingleCNAMEAPI.StatusCode() == 404 {
+	if skyinfoblox.IsNotFound(getSingleCNAMEAPI.Error()) {
 		d.SetId("")
 		return nil
 	}
+	if getSingleCNAMEAPI.Error() != nil {
+		return fmt.Errorf("Infoblox Read Error: %s", getSingleCNAMEAPI.Error())
+	}
 
 	response := getSingleCNAMEAPI.GetResponse()
 	d.SetId(response.Ref)
@@ -137,14 +149,17 @@ func resourceCNAMERead(d *schema.ResourceData, m interface{}) error {
 	d.Set("comment", response.Comment)
 	d.Set("view", response.View)
 	d.Set("ttl", response.TTL)
+	d.Set("use_ttl", response.UseTTL)
 	d.Set("canonical", response.Canonical)
+	d.Set("ref", response.Ref)
+	d.Set("extattrs", flattenExtAttrs(d, m, response.ExtAttrs))
 
 	return nil
 }
 
 func resourceCNAMEUpdate(d *schema.ResourceData, m interface{}) error {
 
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	hasChanges := false
 	resourceReference := d.Id()
 	var updateCNAME records.GenericRecord
@@ -190,6 +205,11 @@ func resourceCNAMEUpdate(d *schema.ResourceData, m interface{}) error {
 		hasChanges = true
 	}
 
+	if d.HasChange("extattrs") {
+		updateCNAME.ExtAttrs = buildExtAttrs(d, m)
+		hasChanges = true
+	}
+
 	if hasChanges {
 		updateAPI := records.NewUpdateRecord(resourceReference, updateCNAME)
 		err := infobloxClient.Do(updateAPI)
@@ -209,7 +229,7 @@ func resourceCNAMEUpdate(d *schema.ResourceData, m interface{}) error {
 func resourceCNAMEDelete(d *schema.ResourceData, m interface{}) error {
 
 	returnFields := []string{}
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	resourceReference := d.Id()
 	getSingleCNAMEAPI := records.NewGetCNAMERecord(resourceReference, returnFields)
 
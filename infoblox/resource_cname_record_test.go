@@ -5,7 +5,6 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/records"
 	"regexp"
 	"testing"
@@ -86,7 +85,7 @@ func testAccInfobloxCNAMEExists(cnameCheck, cnameResourceName string) resource.T
 			return fmt.Errorf("Infoblox CNAME resource ID not set in resources")
 		}
 
-		client := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		client := testAccProvider.Meta().(*providerMeta).client
 		getAllAPI := records.NewGetAllCNAMERecords(returnFields)
 
 		err := client.Do(getAllAPI)
@@ -105,7 +104,7 @@ func testAccInfobloxCNAMEExists(cnameCheck, cnameResourceName string) resource.T
 
 func testAccInfobloxCNAMECheckDestroy(state *terraform.State) error {
 
-	infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
 	returnFields := []string{"name", "comment", "view", "ttl", "canonical"}
 
 	for _, rs := range state.RootModule().Resources {
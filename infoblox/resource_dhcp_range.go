@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/dhcp_range"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
 	"log"
 	"net/http"
 )
@@ -15,6 +16,9 @@ func resourceDHCPRange() *schema.Resource {
 		Read:   resourceDHCPRangeRead,
 		Delete: resourceDHCPRangeDelete,
 		Update: resourceDHCPRangeUpdate,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("range", "start_addr", "end_addr", "network_view"),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"ref": {
@@ -88,13 +92,15 @@ func resourceDHCPRange() *schema.Resource {
 				Description: "Must be set to 'MEMBER' if member is specified",
 				Default:     "NONE",
 			},
+			"extattrs":           util.ExtAttrsSchema(),
+			"inherited_extattrs": util.InheritedExtAttrsSchema(),
 		},
 	}
 }
 
 // resourceDHCPRangeCreate  - Creates a new dhcp range resource
 func resourceDHCPRangeCreate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var rangeCreate dhcprange.DHCPRange
 
 	if v, ok := d.GetOk("name"); ok {
@@ -128,6 +134,7 @@ func resourceDHCPRangeCreate(d *schema.ResourceData, m interface{}) error {
 		flag := v.(bool)
 		rangeCreate.Restart = &flag
 	}
+	rangeCreate.ExtAttrs = buildExtAttrs(d, m)
 
 	createDHCPRangeAPI := dhcprange.NewCreateDHCPRange(rangeCreate)
 	err := infobloxClient.Do(createDHCPRangeAPI)
@@ -135,6 +142,9 @@ func resourceDHCPRangeCreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Error during the DHCP Range creation request: %s", err)
 	}
 
+	if skyinfoblox.IsConflict(createDHCPRangeAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the DHCP range already exists, import it rather than creating it - %s", createDHCPRangeAPI.Error())
+	}
 	if createDHCPRangeAPI.StatusCode() != http.StatusCreated {
 		return fmt.Errorf("Error creating the DHCP Range:\n %s", createDHCPRangeAPI.GetResponse())
 	}
@@ -144,7 +154,7 @@ func resourceDHCPRangeCreate(d *schema.ResourceData, m interface{}) error {
 
 // resourceDHCPRangeDelete  - Delete a network resource
 func resourceDHCPRangeDelete(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	deleteRequest := dhcprange.NewDeleteDHCPRange(d.Id())
 	deleteErr := infobloxClient.Do(deleteRequest)
 	if deleteErr != nil {
@@ -159,20 +169,24 @@ func resourceDHCPRangeDelete(d *schema.ResourceData, m interface{}) error {
 
 // resourceDHCPRangeRead - Reads the resource
 func resourceDHCPRangeRead(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
-	fields := []string{"name", "comment", "end_addr", "start_addr", "network", "network_view", "member", "server_association_type"}
+	infobloxClient := m.(*providerMeta).client
+	fields := []string{"name", "comment", "end_addr", "start_addr", "network", "network_view", "member", "server_association_type", "extattrs"}
 	getDHCPRangeRequest := dhcprange.NewGetDHCPRangeAPI(d.Id(), fields)
 	getErr := infobloxClient.Do(getDHCPRangeRequest)
 	if getErr != nil {
 		return fmt.Errorf("Could not read resource %s", getErr)
 	}
+	if skyinfoblox.IsNotFound(getDHCPRangeRequest.Error()) {
+		d.SetId("")
+		return nil
+	}
 
 	if getDHCPRangeRequest.StatusCode() != http.StatusOK {
-		return fmt.Errorf("HTTP error reading the resource:\n%s", *getDHCPRangeRequest.ResponseObject().(*string))
+		return fmt.Errorf("HTTP error reading the resource:\n%s", getDHCPRangeRequest.Error())
 	}
 	response := getDHCPRangeRequest.GetResponse()
-	d.Set("end_addr", response.End)
-	d.Set("start_addr", response.Start)
+	d.Set("end", response.End)
+	d.Set("start", response.Start)
 	d.Set("network", response.Network)
 	d.Set("network_view", response.NetworkView)
 	d.Set("server_association", response.ServerAssociation)
@@ -180,6 +194,8 @@ func resourceDHCPRangeRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("ref", response.Ref)
 	d.Set("name", response.Name)
 	d.Set("comment", response.Comment)
+	d.Set("extattrs", flattenExtAttrs(d, m, response.ExtAttrs))
+	d.Set("inherited_extattrs", util.BuildInheritedExtAttrsFromIBX(response.ExtAttrs))
 	return nil
 }
 
@@ -187,7 +203,7 @@ func resourceDHCPRangeRead(d *schema.ResourceData, m interface{}) error {
 func resourceDHCPRangeUpdate(d *schema.ResourceData, m interface{}) error {
 	var rangeUpdate dhcprange.DHCPRange
 	var hasChanges bool
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	fields := []string{"name", "comment", "end_addr", "start_addr", "network", "network_view", "member", "server_association_type"}
 	rangeUpdateAPI := dhcprange.NewGetDHCPRangeAPI(d.Id(), fields)
 	getErr := infobloxClient.Do(rangeUpdateAPI)
@@ -248,6 +264,10 @@ func resourceDHCPRangeUpdate(d *schema.ResourceData, m interface{}) error {
 		_, newServerAssociation := d.GetChange("server_association")
 		rangeUpdate.ServerAssociation = newServerAssociation.(string)
 	}
+	if d.HasChange("extattrs") {
+		hasChanges = true
+		rangeUpdate.ExtAttrs = buildExtAttrs(d, m)
+	}
 	if hasChanges {
 		updateRangeAPI := dhcprange.NewUpdateDHCPRange(rangeUpdate)
 		updateErr := infobloxClient.Do(updateRangeAPI)
@@ -287,8 +307,8 @@ func buildMemberObject(memberSet *schema.Set) dhcprange.Member {
 // Flattens member object into a map[string]interface{}
 func flattenMember(member *dhcprange.Member) []map[string]interface{} {
 	result := make([]map[string]interface{}, 0, 1)
-	if member == nil {
-		return nil
+	if member == nil || (member.IPv4Address == "" && member.Name == "") {
+		return result
 	}
 	r := make(map[string]interface{})
 	r["ipv4_addr"] = member.IPv4Address
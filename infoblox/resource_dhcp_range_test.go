@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/dhcp_range"
 	"testing"
 )
@@ -45,7 +44,7 @@ func TestAccResourceDHCPRange(t *testing.T) {
 }
 
 func testAccResourceDHCPRangeDestroy(state *terraform.State) error {
-	infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "infoblox_dhcp_range" {
 			continue
@@ -80,7 +79,7 @@ func testAccResourceDHCPRangeExists(networkAddr, resourceName string) resource.T
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("\nInfoblox DHCP Range resource %s ID not set", resourceName)
 		}
-		infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
 		getRequest := dhcprange.NewGetDHCPRangeAPI(rs.Primary.ID, fields)
 		err := infobloxClient.Do(getRequest)
 		if err != nil {
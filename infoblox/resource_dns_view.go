@@ -0,0 +1,379 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/skyinfoblox/api/view"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+	"net/http"
+)
+
+func resourceDNSView() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDNSViewCreate,
+		Read:   resourceDNSViewRead,
+		Update: resourceDNSViewUpdate,
+		Delete: resourceDNSViewDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("view", "name"),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the DNS view",
+				ValidateFunc: util.CheckLeadingTrailingSpaces,
+			},
+			"comment": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Comment for the DNS view; maximum 256 characters",
+				ValidateFunc: util.CheckLeadingTrailingSpaces,
+			},
+			"disable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Determines whether the DNS view is disabled",
+			},
+			"network_view": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "default",
+				Description:  "The network view the DNS view is associated with",
+				ValidateFunc: util.CheckLeadingTrailingSpaces,
+			},
+			"is_default": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the DNS view is the default one (read-only)",
+			},
+			"match_clients":      util.AccessControlSchema(),
+			"match_destinations": util.AccessControlSchema(),
+			"recursion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Determines whether recursive queries are answered for the clients of the view",
+			},
+			"use_recursion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Use flag for: recursion",
+			},
+			"forwarders": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The addresses of the servers queries are forwarded to",
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: util.ValidateIPAddress},
+			},
+			"forward_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Determines whether queries are only sent to the forwarders, never resolved by the members",
+			},
+			"use_forwarders": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Use flag for: forwarders and forward_only",
+			},
+			"lame_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The number of seconds lame delegations are cached",
+				ValidateFunc: util.ValidateUnsignedInteger,
+			},
+			"use_lame_ttl": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Use flag for: lame_ttl",
+			},
+			"dnssec_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Determines whether the DNS view serves DNSSEC records",
+			},
+			"dnssec_validation_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Determines whether the DNSSEC signatures of recursive answers are validated",
+			},
+			"dnssec_expired_signatures_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Determines whether answers with expired DNSSEC signatures are accepted",
+			},
+			"use_dnssec": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Use flag for: dnssec_enabled, dnssec_validation_enabled and dnssec_expired_signatures_enabled",
+			},
+			"extattrs": util.ExtAttrsSchema(),
+		},
+	}
+}
+
+// dnsViewReturnFields - the fields of a DNS view read back into state
+var dnsViewReturnFields = []string{"name", "comment", "disable", "network_view", "is_default", "match_clients",
+	"match_destinations", "recursion", "use_recursion", "forwarders", "forward_only", "use_forwarders", "lame_ttl",
+	"use_lame_ttl", "dnssec_enabled", "dnssec_validation_enabled", "dnssec_expired_signatures_enabled", "use_dnssec",
+	"extattrs"}
+
+// buildDNSViewAcList - builds the match_clients or match_destinations list of a DNS view
+func buildDNSViewAcList(infobloxClient *skyinfoblox.InfobloxClient, acList []interface{}) (*[]interface{}, error) {
+	builtAc, err := buildAcList(infobloxClient, acList)
+	if err != nil {
+		return nil, err
+	}
+	return &builtAc, nil
+}
+
+// buildDNSViewForwarders - builds the forwarders of a DNS view from the list in state
+func buildDNSViewForwarders(forwarders []interface{}) *[]string {
+	addresses := make([]string, 0)
+	for _, forwarder := range forwarders {
+		addresses = append(addresses, forwarder.(string))
+	}
+	return &addresses
+}
+
+// dnsViewBool - returns a pointer to the value of a boolean attribute
+func dnsViewBool(d *schema.ResourceData, key string) *bool {
+	value := d.Get(key).(bool)
+	return &value
+}
+
+// resourceDNSViewCreate - Creates a new DNS view
+func resourceDNSViewCreate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	var viewCreate view.View
+	var err error
+
+	viewCreate.Name = d.Get("name").(string)
+	if v, ok := d.GetOk("comment"); ok {
+		comment := v.(string)
+		viewCreate.Comment = &comment
+	}
+	viewCreate.Disable = dnsViewBool(d, "disable")
+	viewCreate.NetworkView = d.Get("network_view").(string)
+	if v, ok := d.GetOk("match_clients"); ok {
+		if viewCreate.MatchClients, err = buildDNSViewAcList(infobloxClient, v.([]interface{})); err != nil {
+			return err
+		}
+	}
+	if v, ok := d.GetOk("match_destinations"); ok {
+		if viewCreate.MatchDestinations, err = buildDNSViewAcList(infobloxClient, v.([]interface{})); err != nil {
+			return err
+		}
+	}
+	viewCreate.Recursion = dnsViewBool(d, "recursion")
+	viewCreate.UseRecursion = dnsViewBool(d, "use_recursion")
+	if v, ok := d.GetOk("forwarders"); ok {
+		viewCreate.Forwarders = buildDNSViewForwarders(v.([]interface{}))
+	}
+	viewCreate.ForwardOnly = dnsViewBool(d, "forward_only")
+	viewCreate.UseForwarders = dnsViewBool(d, "use_forwarders")
+	// GetOk can't tell a lame_ttl of 0 from an unset one, so it is always sent while use_lame_ttl is set
+	if v, ok := d.GetOk("lame_ttl"); ok || d.Get("use_lame_ttl").(bool) {
+		lameTTL := uint(v.(int))
+		viewCreate.LameTTL = &lameTTL
+	}
+	viewCreate.UseLameTTL = dnsViewBool(d, "use_lame_ttl")
+	viewCreate.DNSSecEnabled = dnsViewBool(d, "dnssec_enabled")
+	viewCreate.DNSSecValidationEnabled = dnsViewBool(d, "dnssec_validation_enabled")
+	viewCreate.DNSSecExpiredSignaturesEnabled = dnsViewBool(d, "dnssec_expired_signatures_enabled")
+	viewCreate.UseDNSSec = dnsViewBool(d, "use_dnssec")
+	viewCreate.ExtAttrs = buildExtAttrs(d, m)
+
+	createAPI := view.NewCreate(viewCreate)
+	err = infobloxClient.Do(createAPI)
+	if err != nil {
+		return fmt.Errorf("Error creating the DNS view %s: %s", viewCreate.Name, err)
+	}
+	if skyinfoblox.IsConflict(createAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the DNS view already exists, import it rather than creating it - %s", createAPI.Error())
+	}
+	if createAPI.StatusCode() != http.StatusCreated {
+		return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %d returned - response %s", createAPI.StatusCode(), string(createAPI.RawResponse()))
+	}
+	d.SetId(*createAPI.ResponseObject().(*string))
+	return resourceDNSViewRead(d, m)
+}
+
+// resourceDNSViewRead - Reads the resource
+func resourceDNSViewRead(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	getAPI := view.NewGet(d.Id(), dnsViewReturnFields)
+	err := infobloxClient.Do(getAPI)
+	if err != nil {
+		return fmt.Errorf("Could not read the DNS view %s", err)
+	}
+	if skyinfoblox.IsNotFound(getAPI.Error()) {
+		d.SetId("")
+		return nil
+	}
+	if getAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %d returned - response %s", getAPI.StatusCode(), string(getAPI.RawResponse()))
+	}
+
+	dnsView := getAPI.ResponseObject().(*view.View)
+	d.SetId(dnsView.Ref)
+	d.Set("name", dnsView.Name)
+	d.Set("comment", dnsView.Comment)
+	d.Set("disable", dnsView.Disable)
+	d.Set("network_view", dnsView.NetworkView)
+	d.Set("is_default", dnsView.IsDefault)
+	matchClients, matchDestinations := make([]interface{}, 0), make([]interface{}, 0)
+	if dnsView.MatchClients != nil {
+		matchClients = *dnsView.MatchClients
+	}
+	if dnsView.MatchDestinations != nil {
+		matchDestinations = *dnsView.MatchDestinations
+	}
+	d.Set("match_clients", util.BuildAcListFromIBX(matchClients))
+	d.Set("match_destinations", util.BuildAcListFromIBX(matchDestinations))
+	d.Set("recursion", dnsView.Recursion)
+	d.Set("use_recursion", dnsView.UseRecursion)
+	if dnsView.Forwarders != nil {
+		d.Set("forwarders", *dnsView.Forwarders)
+	} else {
+		d.Set("forwarders", []string{})
+	}
+	d.Set("forward_only", dnsView.ForwardOnly)
+	d.Set("use_forwarders", dnsView.UseForwarders)
+	d.Set("lame_ttl", dnsView.LameTTL)
+	d.Set("use_lame_ttl", dnsView.UseLameTTL)
+	d.Set("dnssec_enabled", dnsView.DNSSecEnabled)
+	d.Set("dnssec_validation_enabled", dnsView.DNSSecValidationEnabled)
+	d.Set("dnssec_expired_signatures_enabled", dnsView.DNSSecExpiredSignaturesEnabled)
+	d.Set("use_dnssec", dnsView.UseDNSSec)
+	d.Set("extattrs", flattenExtAttrs(d, m, dnsView.ExtAttrs))
+	return nil
+}
+
+// resourceDNSViewUpdate - Updates the resource
+func resourceDNSViewUpdate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	hasChanges := false
+	var updateView view.View
+	var err error
+	updateView.Ref = d.Id()
+
+	if d.HasChange("name") {
+		updateView.Name = d.Get("name").(string)
+		hasChanges = true
+	}
+	if d.HasChange("comment") {
+		comment := d.Get("comment").(string)
+		updateView.Comment = &comment
+		hasChanges = true
+	}
+	if d.HasChange("network_view") {
+		updateView.NetworkView = d.Get("network_view").(string)
+		hasChanges = true
+	}
+	if d.HasChange("match_clients") {
+		if updateView.MatchClients, err = buildDNSViewAcList(infobloxClient, d.Get("match_clients").([]interface{})); err != nil {
+			return err
+		}
+		hasChanges = true
+	}
+	if d.HasChange("match_destinations") {
+		if updateView.MatchDestinations, err = buildDNSViewAcList(infobloxClient, d.Get("match_destinations").([]interface{})); err != nil {
+			return err
+		}
+		hasChanges = true
+	}
+	if d.HasChange("forwarders") {
+		updateView.Forwarders = buildDNSViewForwarders(d.Get("forwarders").([]interface{}))
+		hasChanges = true
+	}
+	if d.HasChange("lame_ttl") {
+		lameTTL := uint(d.Get("lame_ttl").(int))
+		updateView.LameTTL = &lameTTL
+		hasChanges = true
+	}
+	if d.HasChange("disable") {
+		updateView.Disable = dnsViewBool(d, "disable")
+		hasChanges = true
+	}
+	if d.HasChange("recursion") {
+		updateView.Recursion = dnsViewBool(d, "recursion")
+		hasChanges = true
+	}
+	if d.HasChange("use_recursion") {
+		updateView.UseRecursion = dnsViewBool(d, "use_recursion")
+		hasChanges = true
+	}
+	if d.HasChange("forward_only") {
+		updateView.ForwardOnly = dnsViewBool(d, "forward_only")
+		hasChanges = true
+	}
+	if d.HasChange("use_forwarders") {
+		updateView.UseForwarders = dnsViewBool(d, "use_forwarders")
+		hasChanges = true
+	}
+	if d.HasChange("use_lame_ttl") {
+		updateView.UseLameTTL = dnsViewBool(d, "use_lame_ttl")
+		hasChanges = true
+	}
+	if d.HasChange("dnssec_enabled") {
+		updateView.DNSSecEnabled = dnsViewBool(d, "dnssec_enabled")
+		hasChanges = true
+	}
+	if d.HasChange("dnssec_validation_enabled") {
+		updateView.DNSSecValidationEnabled = dnsViewBool(d, "dnssec_validation_enabled")
+		hasChanges = true
+	}
+	if d.HasChange("dnssec_expired_signatures_enabled") {
+		updateView.DNSSecExpiredSignaturesEnabled = dnsViewBool(d, "dnssec_expired_signatures_enabled")
+		hasChanges = true
+	}
+	if d.HasChange("use_dnssec") {
+		updateView.UseDNSSec = dnsViewBool(d, "use_dnssec")
+		hasChanges = true
+	}
+	if d.HasChange("extattrs") {
+		updateView.ExtAttrs = buildExtAttrs(d, m)
+		hasChanges = true
+	}
+
+	if hasChanges {
+		updateAPI := view.NewUpdate(updateView)
+		err = infobloxClient.Do(updateAPI)
+		if err != nil {
+			return fmt.Errorf("Error updating the DNS view %s", err)
+		}
+		if updateAPI.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Infoblox Update Error: Invalid HTTP response code %d returned - response %s", updateAPI.StatusCode(), string(updateAPI.RawResponse()))
+		}
+		d.SetId(*updateAPI.ResponseObject().(*string))
+	}
+	return resourceDNSViewRead(d, m)
+}
+
+// resourceDNSViewDelete - Deletes the resource
+func resourceDNSViewDelete(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	deleteAPI := view.NewDelete(d.Id())
+	err := infobloxClient.Do(deleteAPI)
+	if err != nil {
+		return fmt.Errorf("Could not delete the DNS view %s", err)
+	}
+	if deleteAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Delete Error: Invalid HTTP response code %d returned - response %s", deleteAPI.StatusCode(), string(deleteAPI.RawResponse()))
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,226 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/sky-uk/skyinfoblox/api/view"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func TestAccResourceDNSView(t *testing.T) {
+	name := fmt.Sprintf("acctest-dns-view-%d", acctest.RandInt())
+	resourceName := "infoblox_dns_view.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			if err := testAccResourceNamedACLDestroy(state); err != nil {
+				return err
+			}
+			return testAccResourceDNSViewDestroy(state)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceDNSViewInvalidForwarderTemplate(name),
+				ExpectError: regexp.MustCompile(`must be an IPv4 or IPv6 address`),
+			},
+			{
+				Config: testAccResourceDNSViewCreateTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceDNSViewExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "comment", "internal clients"),
+					resource.TestCheckResourceAttr(resourceName, "network_view", "default"),
+					resource.TestCheckResourceAttr(resourceName, "is_default", "false"),
+					resource.TestCheckResourceAttr(resourceName, "match_clients.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "match_clients.0.type", "namedacl"),
+					resource.TestCheckResourceAttr(resourceName, "match_clients.0.name", name),
+					resource.TestCheckResourceAttr(resourceName, "match_clients.1.address", "192.168.0.0/16"),
+					resource.TestCheckResourceAttr(resourceName, "match_destinations.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "recursion", "true"),
+					resource.TestCheckResourceAttr(resourceName, "use_recursion", "true"),
+					resource.TestCheckResourceAttr(resourceName, "forwarders.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "forwarders.0", "10.0.0.53"),
+					resource.TestCheckResourceAttr(resourceName, "forward_only", "true"),
+					resource.TestCheckResourceAttr(resourceName, "use_forwarders", "true"),
+					resource.TestCheckResourceAttr(resourceName, "lame_ttl", "300"),
+					resource.TestCheckResourceAttr(resourceName, "use_lame_ttl", "true"),
+					resource.TestCheckResourceAttr(resourceName, "dnssec_validation_enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "use_dnssec", "true"),
+					resource.TestCheckResourceAttr("infoblox_zone_auth.acctest", "view", name),
+				),
+			},
+			{
+				Config: testAccResourceDNSViewUpdateTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceDNSViewExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "comment", "external clients"),
+					resource.TestCheckResourceAttr(resourceName, "match_clients.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "match_clients.0.address", "any"),
+					resource.TestCheckResourceAttr(resourceName, "match_destinations.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "recursion", "false"),
+					resource.TestCheckResourceAttr(resourceName, "forwarders.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "use_forwarders", "false"),
+					resource.TestCheckResourceAttr(resourceName, "dnssec_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "dnssec_validation_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "extattrs.%", "1"),
+				),
+			},
+			{
+				Config:            testAccResourceDNSViewUpdateTemplate(name),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     name,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceDNSViewZeroLameTTL(t *testing.T) {
+	name := fmt.Sprintf("acctest-dns-view-%d", acctest.RandInt())
+	resourceName := "infoblox_dns_view.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceDNSViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDNSViewZeroLameTTLTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceDNSViewExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "lame_ttl", "0"),
+					resource.TestCheckResourceAttr(resourceName, "use_lame_ttl", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceDNSViewDestroy(state *terraform.State) error {
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "infoblox_dns_view" {
+			continue
+		}
+		api := view.NewGet(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusNotFound {
+			return fmt.Errorf("DNS view %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccResourceDNSViewExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("\nInfoblox DNS view resource %s not found in resources", resourceName)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("\nInfoblox DNS view resource %s ID not set", resourceName)
+		}
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
+		api := view.NewGet(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Could not find %s", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccResourceDNSViewInvalidForwarderTemplate(name string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_dns_view" "acctest" {
+	name = "%s"
+	forwarders = ["ns1.example.com"]
+	}`, name)
+}
+
+func testAccResourceDNSViewCreateTemplate(name string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_named_acl" "acctest" {
+	name = "%s"
+	access_list {
+	  type = "addressac"
+	  address = "10.0.0.0/8"
+	  permission = "ALLOW"
+	}
+	}
+
+	resource "infoblox_dns_view" "acctest" {
+	name = "%s"
+	comment = "internal clients"
+	match_clients {
+	  type = "namedacl"
+	  name = "${infoblox_named_acl.acctest.name}"
+	}
+	match_clients {
+	  type = "addressac"
+	  address = "192.168.0.0/16"
+	  permission = "ALLOW"
+	}
+	match_destinations {
+	  type = "addressac"
+	  address = "10.1.1.1"
+	  permission = "ALLOW"
+	}
+	recursion = true
+	use_recursion = true
+	forwarders = ["10.0.0.53", "10.0.1.53"]
+	forward_only = true
+	use_forwarders = true
+	lame_ttl = 300
+	use_lame_ttl = true
+	dnssec_validation_enabled = false
+	use_dnssec = true
+	}
+
+	resource "infoblox_zone_auth" "acctest" {
+	fqdn = "%s.example.com"
+	view = "${infoblox_dns_view.acctest.name}"
+	}`, name, name, name)
+}
+
+func testAccResourceDNSViewZeroLameTTLTemplate(name string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_dns_view" "acctest" {
+	name = "%s"
+	lame_ttl = 0
+	use_lame_ttl = true
+	}`, name)
+}
+
+func testAccResourceDNSViewUpdateTemplate(name string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_dns_view" "acctest" {
+	name = "%s"
+	comment = "external clients"
+	match_clients {
+	  type = "addressac"
+	  address = "any"
+	  permission = "ALLOW"
+	}
+	lame_ttl = 300
+	use_lame_ttl = true
+	dnssec_enabled = true
+	use_dnssec = true
+	extattrs {
+	  Site = "London"
+	}
+	}`, name)
+}
@@ -0,0 +1,285 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/skyinfoblox/api/extensibleattributedef"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+	"net/http"
+)
+
+func resourceExtensibleAttributeDefinition() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceExtensibleAttributeDefinitionCreate,
+		Read:   resourceExtensibleAttributeDefinitionRead,
+		Update: resourceExtensibleAttributeDefinitionUpdate,
+		Delete: resourceExtensibleAttributeDefinitionDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("extensibleattributedef", "name"),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"ref": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique reference to Infoblox extensible attribute definition resource",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the extensible attribute",
+				ValidateFunc: util.CheckLeadingTrailingSpaces,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The type of the attribute values: STRING, INTEGER, ENUM, EMAIL, URL or DATE",
+				ValidateFunc: util.ValidateExtAttrType,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"default_value": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The value given to the attribute of new objects",
+			},
+			"list_values": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The values allowed for an ENUM attribute",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The minimum value of an INTEGER attribute, or the minimum length of a STRING attribute",
+				ValidateFunc: util.ValidateUnsignedInteger,
+			},
+			"max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum value of an INTEGER attribute, or the maximum length of a STRING attribute",
+				ValidateFunc: util.ValidateUnsignedInteger,
+			},
+			"flags": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The WAPI flags of the attribute, e.g. I for inheritable, M for mandatory, L for listed, " +
+					"S for searchable, A for allowed in advanced search, R for read only",
+				ValidateFunc:     util.ValidateExtAttrFlags,
+				DiffSuppressFunc: util.SuppressEquivalentExtAttrFlags,
+			},
+			"allowed_object_types": {
+				// Need to use TypeSet as the read order doesn't match the sent order.
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The WAPI object types the attribute can be set on, e.g. Network or ARecord. All types are allowed when empty",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// buildExtAttrDefListValues - builds the allowed values of an ENUM attribute from the list in state
+func buildExtAttrDefListValues(values []interface{}) []extensibleattributedef.ListValue {
+	listValues := make([]extensibleattributedef.ListValue, 0)
+	for _, value := range values {
+		listValues = append(listValues, extensibleattributedef.ListValue{Value: value.(string)})
+	}
+	return listValues
+}
+
+// flattenExtAttrDefListValues - builds the list of allowed values of an ENUM attribute for state
+func flattenExtAttrDefListValues(listValues []extensibleattributedef.ListValue) []string {
+	values := make([]string, 0)
+	for _, listValue := range listValues {
+		values = append(values, listValue.Value)
+	}
+	return values
+}
+
+// buildExtAttrDefAllowedObjectTypes - builds the allowed object types from the set in state
+func buildExtAttrDefAllowedObjectTypes(objectTypes *schema.Set) *[]string {
+	allowedObjectTypes := make([]string, 0)
+	for _, objectType := range objectTypes.List() {
+		allowedObjectTypes = append(allowedObjectTypes, objectType.(string))
+	}
+	return &allowedObjectTypes
+}
+
+// resourceExtensibleAttributeDefinitionCreate - Creates a new extensible attribute definition
+func resourceExtensibleAttributeDefinitionCreate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	var definitionCreate extensibleattributedef.ExtensibleAttributeDef
+
+	definitionCreate.Name = d.Get("name").(string)
+	definitionCreate.Type = d.Get("type").(string)
+	if v, ok := d.GetOk("comment"); ok {
+		comment := v.(string)
+		definitionCreate.Comment = &comment
+	}
+	if v, ok := d.GetOk("default_value"); ok {
+		defaultValue := v.(string)
+		definitionCreate.DefaultValue = &defaultValue
+	}
+	if v, ok := d.GetOk("list_values"); ok {
+		listValues := buildExtAttrDefListValues(v.([]interface{}))
+		definitionCreate.ListValues = &listValues
+	}
+	if v, ok := d.GetOk("min"); ok {
+		min := v.(int)
+		definitionCreate.Min = &min
+	}
+	if v, ok := d.GetOk("max"); ok {
+		max := v.(int)
+		definitionCreate.Max = &max
+	}
+	if v, ok := d.GetOk("flags"); ok {
+		flags := v.(string)
+		definitionCreate.Flags = &flags
+	}
+	if v, ok := d.GetOk("allowed_object_types"); ok {
+		definitionCreate.AllowedObjectTypes = buildExtAttrDefAllowedObjectTypes(v.(*schema.Set))
+	}
+
+	createAPI := extensibleattributedef.NewCreate(definitionCreate)
+	err := infobloxClient.Do(createAPI)
+	if err != nil {
+		return fmt.Errorf("Error creating the extensible attribute definition %s: %s", definitionCreate.Name, err)
+	}
+	if skyinfoblox.IsConflict(createAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the extensible attribute definition already exists, import it rather than creating it - %s", createAPI.Error())
+	}
+	if createAPI.StatusCode() != http.StatusCreated {
+		return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %d returned - response %s", createAPI.StatusCode(), *createAPI.ResponseObject().(*string))
+	}
+	d.SetId(*createAPI.ResponseObject().(*string))
+	return resourceExtensibleAttributeDefinitionRead(d, m)
+}
+
+// resourceExtensibleAttributeDefinitionRead - Reads the resource
+func resourceExtensibleAttributeDefinitionRead(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	fields := []string{"name", "type", "comment", "default_value", "list_values", "min", "max", "flags", "allowed_object_types"}
+	fields, err := supportedFields(m, extensibleattributedef.Endpoint, fields)
+	if err != nil {
+		return err
+	}
+	getAPI := extensibleattributedef.NewGet(d.Id(), fields)
+	err = infobloxClient.Do(getAPI)
+	if err != nil {
+		return fmt.Errorf("Could not read the extensible attribute definition %s", err)
+	}
+	if skyinfoblox.IsNotFound(getAPI.Error()) {
+		d.SetId("")
+		return nil
+	}
+	if getAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %d returned - response %s", getAPI.StatusCode(), string(getAPI.RawResponse()))
+	}
+
+	definition := getAPI.ResponseObject().(*extensibleattributedef.ExtensibleAttributeDef)
+	d.SetId(definition.Ref)
+	d.Set("ref", definition.Ref)
+	d.Set("name", definition.Name)
+	d.Set("type", definition.Type)
+	d.Set("comment", definition.Comment)
+	d.Set("default_value", definition.DefaultValue)
+	if definition.ListValues != nil {
+		d.Set("list_values", flattenExtAttrDefListValues(*definition.ListValues))
+	} else {
+		d.Set("list_values", []string{})
+	}
+	d.Set("min", definition.Min)
+	d.Set("max", definition.Max)
+	d.Set("flags", definition.Flags)
+	if definition.AllowedObjectTypes != nil {
+		d.Set("allowed_object_types", *definition.AllowedObjectTypes)
+	} else {
+		d.Set("allowed_object_types", []string{})
+	}
+	return nil
+}
+
+// resourceExtensibleAttributeDefinitionUpdate - Updates the resource
+func resourceExtensibleAttributeDefinitionUpdate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	hasChanges := false
+	var updateDefinition extensibleattributedef.ExtensibleAttributeDef
+	updateDefinition.Ref = d.Id()
+
+	if d.HasChange("name") {
+		updateDefinition.Name = d.Get("name").(string)
+		hasChanges = true
+	}
+	if d.HasChange("comment") {
+		comment := d.Get("comment").(string)
+		updateDefinition.Comment = &comment
+		hasChanges = true
+	}
+	if d.HasChange("default_value") {
+		defaultValue := d.Get("default_value").(string)
+		updateDefinition.DefaultValue = &defaultValue
+		hasChanges = true
+	}
+	if d.HasChange("list_values") {
+		// an empty list is still sent, so removing every value clears them
+		listValues := buildExtAttrDefListValues(d.Get("list_values").([]interface{}))
+		updateDefinition.ListValues = &listValues
+		hasChanges = true
+	}
+	if d.HasChange("min") {
+		min := d.Get("min").(int)
+		updateDefinition.Min = &min
+		hasChanges = true
+	}
+	if d.HasChange("max") {
+		max := d.Get("max").(int)
+		updateDefinition.Max = &max
+		hasChanges = true
+	}
+	if d.HasChange("flags") {
+		flags := d.Get("flags").(string)
+		updateDefinition.Flags = &flags
+		hasChanges = true
+	}
+	if d.HasChange("allowed_object_types") {
+		updateDefinition.AllowedObjectTypes = buildExtAttrDefAllowedObjectTypes(d.Get("allowed_object_types").(*schema.Set))
+		hasChanges = true
+	}
+
+	if hasChanges {
+		updateAPI := extensibleattributedef.NewUpdate(updateDefinition)
+		err := infobloxClient.Do(updateAPI)
+		if err != nil {
+			return fmt.Errorf("Error updating the extensible attribute definition %s", err)
+		}
+		if updateAPI.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Infoblox Update Error: Invalid HTTP response code %d returned - response %s", updateAPI.StatusCode(), string(updateAPI.RawResponse()))
+		}
+		d.SetId(*updateAPI.ResponseObject().(*string))
+		// the type or name the provider cached may have changed
+		forgetExtAttrTypes(m)
+	}
+	return resourceExtensibleAttributeDefinitionRead(d, m)
+}
+
+// resourceExtensibleAttributeDefinitionDelete - Deletes the resource
+func resourceExtensibleAttributeDefinitionDelete(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	deleteAPI := extensibleattributedef.NewDelete(d.Id())
+	err := infobloxClient.Do(deleteAPI)
+	if err != nil {
+		return fmt.Errorf("Could not delete the extensible attribute definition %s", err)
+	}
+	if deleteAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Delete Error: Invalid HTTP response code %d returned - response %s", deleteAPI.StatusCode(), string(deleteAPI.RawResponse()))
+	}
+	forgetExtAttrTypes(m)
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,198 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/sky-uk/skyinfoblox/api/extensibleattributedef"
+	"net/http"
+	"testing"
+)
+
+func TestAccResourceExtensibleAttributeDefinition(t *testing.T) {
+	name := fmt.Sprintf("acctest-ea-%d", acctest.RandInt())
+	resourceName := "infoblox_extensible_attribute_definition.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceExtensibleAttributeDefinitionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceExtensibleAttributeDefinitionCreateTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceExtensibleAttributeDefinitionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "type", "ENUM"),
+					resource.TestCheckResourceAttr(resourceName, "list_values.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "list_values.0", "production"),
+					resource.TestCheckResourceAttr(resourceName, "default_value", "production"),
+					resource.TestCheckResourceAttr(resourceName, "allowed_object_types.#", "1"),
+				),
+			},
+			{
+				Config: testAccResourceExtensibleAttributeDefinitionUpdateTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceExtensibleAttributeDefinitionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "comment", "an updated attribute"),
+					resource.TestCheckResourceAttr(resourceName, "list_values.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "flags", "I"),
+					resource.TestCheckResourceAttr(resourceName, "allowed_object_types.#", "2"),
+				),
+			},
+			{
+				Config:            testAccResourceExtensibleAttributeDefinitionUpdateTemplate(name),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     name,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccResourceExtensibleAttributeDefinitionClearTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceExtensibleAttributeDefinitionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "list_values.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "default_value", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceExtensibleAttributeDefinitionInteger(t *testing.T) {
+	name := fmt.Sprintf("acctest-ea-int-%d", acctest.RandInt())
+	resourceName := "infoblox_admin_role.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceExtensibleAttributeDefinitionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceExtensibleAttributeDefinitionIntegerTemplate(name, "120"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "extattrs.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "extattrs."+name, "120"),
+				),
+			},
+			{
+				Config: testAccResourceExtensibleAttributeDefinitionIntegerTemplate(name, "4096"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "extattrs."+name, "4096"),
+				),
+			},
+			{
+				Config: testAccResourceExtensibleAttributeDefinitionNoExtAttrsTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "extattrs.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceExtensibleAttributeDefinitionDestroy(state *terraform.State) error {
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "infoblox_extensible_attribute_definition" {
+			continue
+		}
+		api := extensibleattributedef.NewGet(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusNotFound {
+			return fmt.Errorf("Extensible attribute definition %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccResourceExtensibleAttributeDefinitionExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("\nInfoblox extensible attribute definition resource %s not found in resources", resourceName)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("\nInfoblox extensible attribute definition resource %s ID not set", resourceName)
+		}
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
+		api := extensibleattributedef.NewGet(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Could not find %s", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccResourceExtensibleAttributeDefinitionCreateTemplate(name string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_extensible_attribute_definition" "acctest" {
+	name = "%s"
+	type = "ENUM"
+	list_values = ["production", "staging"]
+	default_value = "production"
+	flags = "MI"
+	allowed_object_types = ["Network"]
+	}`, name)
+}
+
+func testAccResourceExtensibleAttributeDefinitionUpdateTemplate(name string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_extensible_attribute_definition" "acctest" {
+	name = "%s"
+	type = "ENUM"
+	comment = "an updated attribute"
+	list_values = ["production", "staging", "development"]
+	default_value = "production"
+	flags = "I"
+	allowed_object_types = ["Network", "NetworkContainer"]
+	}`, name)
+}
+
+// testAccResourceExtensibleAttributeDefinitionClearTemplate - drops every allowed value of the ENUM attribute
+func testAccResourceExtensibleAttributeDefinitionClearTemplate(name string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_extensible_attribute_definition" "acctest" {
+	name = "%s"
+	type = "ENUM"
+	comment = "an updated attribute"
+	flags = "I"
+	allowed_object_types = ["Network", "NetworkContainer"]
+	}`, name)
+}
+
+func testAccResourceExtensibleAttributeDefinitionIntegerTemplate(name, value string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_extensible_attribute_definition" "acctest" {
+	name = "%s"
+	type = "INTEGER"
+	}
+
+	resource "infoblox_admin_role" "acctest" {
+	name = "%s"
+	extattrs {
+	  "%s" = "%s"
+	}
+	depends_on = ["infoblox_extensible_attribute_definition.acctest"]
+	}`, name, name, name, value)
+}
+
+func testAccResourceExtensibleAttributeDefinitionNoExtAttrsTemplate(name string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_extensible_attribute_definition" "acctest" {
+	name = "%s"
+	type = "INTEGER"
+	}
+
+	resource "infoblox_admin_role" "acctest" {
+	name = "%s"
+	}`, name, name)
+}
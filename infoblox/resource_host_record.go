@@ -0,0 +1,358 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/skyinfoblox/api/hostrecord"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+	"net/http"
+)
+
+func resourceHostRecord() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceHostRecordCreate,
+		Read:   resourceHostRecordRead,
+		Update: resourceHostRecordUpdate,
+		Delete: resourceHostRecordDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("record:host", "name", "view"),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"ref": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Internal Reference for the record",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The FQDN of the host",
+				ValidateFunc: util.CheckLeadingTrailingSpaces,
+			},
+			"view": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The DNS view in which the record resides",
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "DNS Zone for the record",
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "TTL in seconds for the record",
+				ValidateFunc: util.ValidateUnsignedInteger,
+			},
+			"use_ttl": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"disable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"configure_for_dns": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "When false the host does not have associated DNS records",
+			},
+			"aliases": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Alternative FQDNs of the host",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ipv4addrs": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "The IPv4 addresses of the host, in a significant order: reordering them is a change of each entry",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ipv4addr": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The IPv4 address, computed when allocate_from is used",
+						},
+						"allocate_from": allocateFromIPSchema(nil),
+						"mac": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The MAC address of the interface",
+						},
+						"configure_for_dhcp": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Serve the address over DHCP to the interface with the given MAC address",
+						},
+					},
+				},
+			},
+			"extattrs": util.ExtAttrsSchema(),
+		},
+	}
+}
+
+// buildHostIPv4Addrs - builds the list of host addresses from the ipv4addrs list in state
+func buildHostIPv4Addrs(ipv4AddrsFromT []interface{}) ([]hostrecord.IPv4Address, error) {
+	ipv4Addrs := make([]hostrecord.IPv4Address, 0)
+	for idx, item := range ipv4AddrsFromT {
+		addressFromT := item.(map[string]interface{})
+		var address hostrecord.IPv4Address
+
+		if v, ok := addressFromT["ipv4addr"].(string); ok && v != "" {
+			address.IPv4Addr = v
+		} else if v, ok := addressFromT["allocate_from"].([]interface{}); ok && len(v) > 0 {
+			nextAvailableIP, err := buildNextAvailableIP(v[0].(map[string]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			address.IPv4Addr = nextAvailableIP
+		} else {
+			return nil, fmt.Errorf("ipv4addrs.%d requires one of ipv4addr or allocate_from", idx)
+		}
+
+		if v, ok := addressFromT["mac"].(string); ok && v != "" {
+			address.Mac = v
+		}
+		if v, ok := addressFromT["configure_for_dhcp"].(bool); ok {
+			address.ConfigureForDHCP = &v
+		}
+		ipv4Addrs = append(ipv4Addrs, address)
+	}
+	return ipv4Addrs, nil
+}
+
+// flattenHostIPv4Addrs - builds the ipv4addrs list for terraform given the addresses from IBX.
+// The grid doesn't keep the order of the addresses, so they are matched to the entries in the current state by
+// address, and the addresses the grid just allocated fill the entries without one, in order. allocate_from is not
+// returned by the grid, so it is kept from the matching entry. Addresses not in state come last.
+func flattenHostIPv4Addrs(IBXIPv4Addrs []hostrecord.IPv4Address, ipv4AddrsFromT []interface{}) []map[string]interface{} {
+	remaining := append([]hostrecord.IPv4Address{}, IBXIPv4Addrs...)
+	matches := make([]*hostrecord.IPv4Address, len(ipv4AddrsFromT))
+	for idx, item := range ipv4AddrsFromT {
+		addressFromT, _ := item.(map[string]interface{})
+		if v, _ := addressFromT["ipv4addr"].(string); v != "" {
+			for remainingIdx, IBXAddress := range remaining {
+				if fmt.Sprint(IBXAddress.IPv4Addr) == v {
+					match := IBXAddress
+					matches[idx] = &match
+					remaining = append(remaining[:remainingIdx], remaining[remainingIdx+1:]...)
+					break
+				}
+			}
+		}
+	}
+	for idx, item := range ipv4AddrsFromT {
+		addressFromT, _ := item.(map[string]interface{})
+		if v, _ := addressFromT["ipv4addr"].(string); v == "" && len(remaining) > 0 {
+			matches[idx] = &remaining[0]
+			remaining = remaining[1:]
+		}
+	}
+
+	ipv4Addrs := make([]map[string]interface{}, 0)
+	for idx, IBXAddress := range matches {
+		if IBXAddress != nil {
+			address := flattenHostIPv4Addr(*IBXAddress)
+			address["allocate_from"] = ipv4AddrsFromT[idx].(map[string]interface{})["allocate_from"]
+			ipv4Addrs = append(ipv4Addrs, address)
+		}
+	}
+	for _, IBXAddress := range remaining {
+		ipv4Addrs = append(ipv4Addrs, flattenHostIPv4Addr(IBXAddress))
+	}
+	return ipv4Addrs
+}
+
+func flattenHostIPv4Addr(IBXAddress hostrecord.IPv4Address) map[string]interface{} {
+	address := make(map[string]interface{})
+	address["ipv4addr"] = IBXAddress.IPv4Addr
+	address["mac"] = IBXAddress.Mac
+	if IBXAddress.ConfigureForDHCP != nil {
+		address["configure_for_dhcp"] = *IBXAddress.ConfigureForDHCP
+	}
+	return address
+}
+
+func buildHostAliases(aliasesFromT []interface{}) *[]string {
+	aliases := make([]string, 0)
+	for _, alias := range aliasesFromT {
+		aliases = append(aliases, alias.(string))
+	}
+	return &aliases
+}
+
+func resourceHostRecordCreate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	var hostRecord hostrecord.HostRecord
+
+	hostRecord.Name = d.Get("name").(string)
+	if v, ok := d.GetOk("view"); ok {
+		hostRecord.View = v.(string)
+	}
+	if v, ok := d.GetOk("comment"); ok {
+		comment := v.(string)
+		hostRecord.Comment = &comment
+	}
+	if v, ok := d.GetOk("ttl"); ok {
+		hostRecord.TTL = uint(v.(int))
+	}
+	useTTL := d.Get("use_ttl").(bool)
+	hostRecord.UseTTL = &useTTL
+	disable := d.Get("disable").(bool)
+	hostRecord.Disable = &disable
+	configureForDNS := d.Get("configure_for_dns").(bool)
+	hostRecord.ConfigureForDNS = &configureForDNS
+	if v, ok := d.GetOk("aliases"); ok {
+		hostRecord.Aliases = buildHostAliases(v.([]interface{}))
+	}
+	hostRecord.ExtAttrs = buildExtAttrs(d, m)
+
+	ipv4Addrs, err := buildHostIPv4Addrs(d.Get("ipv4addrs").([]interface{}))
+	if err != nil {
+		return err
+	}
+	hostRecord.IPv4Addrs = ipv4Addrs
+
+	createAPI := hostrecord.NewCreate(hostRecord)
+	err = infobloxClient.Do(createAPI)
+	if err != nil {
+		return fmt.Errorf("Infoblox Create Error: %+v", err)
+	}
+	if skyinfoblox.IsConflict(createAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the host record already exists, import it rather than creating it - %s", createAPI.Error())
+	}
+	if createAPI.StatusCode() != http.StatusCreated {
+		return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %d returned - response %s", createAPI.StatusCode(), *createAPI.ResponseObject().(*string))
+	}
+	d.SetId(*createAPI.ResponseObject().(*string))
+	return resourceHostRecordRead(d, m)
+}
+
+func resourceHostRecordRead(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	returnFields := []string{"name", "view", "zone", "comment", "ttl", "use_ttl", "disable", "configure_for_dns", "aliases", "ipv4addrs", "extattrs"}
+	getAPI := hostrecord.NewGet(d.Id(), returnFields)
+	err := infobloxClient.Do(getAPI)
+	if err != nil {
+		return fmt.Errorf("Infoblox Read Error: %+v", err)
+	}
+	if skyinfoblox.IsNotFound(getAPI.Error()) {
+		d.SetId("")
+		return nil
+	}
+	if getAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %d returned - response %s", getAPI.StatusCode(), string(getAPI.RawResponse()))
+	}
+
+	hostRecord := getAPI.ResponseObject().(*hostrecord.HostRecord)
+	d.SetId(hostRecord.Ref)
+	d.Set("ref", hostRecord.Ref)
+	d.Set("name", hostRecord.Name)
+	d.Set("view", hostRecord.View)
+	d.Set("zone", hostRecord.Zone)
+	d.Set("comment", hostRecord.Comment)
+	d.Set("ttl", hostRecord.TTL)
+	d.Set("use_ttl", hostRecord.UseTTL)
+	d.Set("disable", hostRecord.Disable)
+	d.Set("configure_for_dns", hostRecord.ConfigureForDNS)
+	if hostRecord.Aliases != nil {
+		d.Set("aliases", *hostRecord.Aliases)
+	} else {
+		d.Set("aliases", []string{})
+	}
+	d.Set("ipv4addrs", flattenHostIPv4Addrs(hostRecord.IPv4Addrs, d.Get("ipv4addrs").([]interface{})))
+	d.Set("extattrs", flattenExtAttrs(d, m, hostRecord.ExtAttrs))
+	return nil
+}
+
+func resourceHostRecordUpdate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	var hostRecord hostrecord.HostRecord
+	hasChanges := false
+	hostRecord.Ref = d.Id()
+
+	if d.HasChange("name") {
+		hostRecord.Name = d.Get("name").(string)
+		hasChanges = true
+	}
+	if d.HasChange("comment") {
+		comment := d.Get("comment").(string)
+		hostRecord.Comment = &comment
+		hasChanges = true
+	}
+	if d.HasChange("ttl") {
+		hostRecord.TTL = uint(d.Get("ttl").(int))
+		hasChanges = true
+	}
+	if d.HasChange("use_ttl") {
+		useTTL := d.Get("use_ttl").(bool)
+		hostRecord.UseTTL = &useTTL
+		hasChanges = true
+	}
+	if d.HasChange("disable") {
+		disable := d.Get("disable").(bool)
+		hostRecord.Disable = &disable
+		hasChanges = true
+	}
+	if d.HasChange("configure_for_dns") {
+		configureForDNS := d.Get("configure_for_dns").(bool)
+		hostRecord.ConfigureForDNS = &configureForDNS
+		hasChanges = true
+	}
+	if d.HasChange("aliases") {
+		hostRecord.Aliases = buildHostAliases(d.Get("aliases").([]interface{}))
+		hasChanges = true
+	}
+	if d.HasChange("ipv4addrs") {
+		ipv4Addrs, err := buildHostIPv4Addrs(d.Get("ipv4addrs").([]interface{}))
+		if err != nil {
+			return err
+		}
+		hostRecord.IPv4Addrs = ipv4Addrs
+		hasChanges = true
+	}
+	if d.HasChange("extattrs") {
+		hostRecord.ExtAttrs = buildExtAttrs(d, m)
+		hasChanges = true
+	}
+
+	if hasChanges {
+		updateAPI := hostrecord.NewUpdate(hostRecord)
+		err := infobloxClient.Do(updateAPI)
+		if err != nil {
+			return fmt.Errorf("Infoblox Update Error: %+v", err)
+		}
+		if updateAPI.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Infoblox Update Error: Invalid HTTP response code %d returned - response %s", updateAPI.StatusCode(), string(updateAPI.RawResponse()))
+		}
+		d.SetId(*updateAPI.ResponseObject().(*string))
+	}
+	return resourceHostRecordRead(d, m)
+}
+
+func resourceHostRecordDelete(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	deleteAPI := hostrecord.NewDelete(d.Id())
+	err := infobloxClient.Do(deleteAPI)
+	if err != nil {
+		return fmt.Errorf("Infoblox Delete Error: %+v", err)
+	}
+	if deleteAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Delete Error: Invalid HTTP response code %d returned - response %s", deleteAPI.StatusCode(), string(deleteAPI.RawResponse()))
+	}
+	d.SetId("")
+	return nil
+}
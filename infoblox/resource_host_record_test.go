@@ -0,0 +1,197 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/sky-uk/skyinfoblox/api/hostrecord"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAccResourceHostRecord(t *testing.T) {
+	randInt := acctest.RandInt()
+	hostName := fmt.Sprintf("host-record-test-%d.slupaas.bskyb.com", randInt)
+	networkAddr := fmt.Sprintf("10.3.%d.0/24", acctest.RandIntRange(0, 255))
+	resourceName := "infoblox_host_record.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceHostRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceHostRecordCreateTemplate(hostName, networkAddr),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceHostRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", hostName),
+					resource.TestCheckResourceAttr(resourceName, "view", "default"),
+					resource.TestCheckResourceAttr(resourceName, "ipv4addrs.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "ipv4addrs.0.ipv4addr", "10.0.0.20"),
+					resource.TestCheckResourceAttr(resourceName, "ipv4addrs.0.mac", "aa:bb:cc:dd:ee:ff"),
+					resource.TestCheckResourceAttrSet(resourceName, "ipv4addrs.1.ipv4addr"),
+					resource.TestCheckResourceAttr(resourceName, "aliases.#", "1"),
+				),
+			},
+			{
+				Config: testAccResourceHostRecordUpdateTemplate(hostName, networkAddr),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceHostRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "comment", "an updated host record"),
+					resource.TestCheckResourceAttr(resourceName, "ipv4addrs.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "aliases.#", "0"),
+				),
+			},
+			{
+				Config:                  testAccResourceHostRecordUpdateTemplate(hostName, networkAddr),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           hostName + "/default",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ipv4addrs.1.allocate_from"},
+			},
+			{
+				Config: testAccResourceHostRecordNoCommentTemplate(hostName, networkAddr),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceHostRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "comment", ""),
+					resource.TestCheckResourceAttr(resourceName, "ipv4addrs.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenHostIPv4Addrs(t *testing.T) {
+	allocateFrom := []interface{}{map[string]interface{}{"network": "10.3.0.0/24"}}
+	IBXIPv4Addrs := []hostrecord.IPv4Address{{IPv4Addr: "10.3.0.1"}, {IPv4Addr: "10.0.0.30"}, {IPv4Addr: "10.0.0.20", Mac: "aa:bb:cc:dd:ee:ff"}}
+
+	// the grid returns the addresses in another order, with the one it allocated first
+	ipv4Addrs := flattenHostIPv4Addrs(IBXIPv4Addrs, []interface{}{
+		map[string]interface{}{"ipv4addr": "10.0.0.20", "mac": "aa:bb:cc:dd:ee:ff"},
+		map[string]interface{}{"ipv4addr": "", "allocate_from": allocateFrom},
+	})
+	expected := []map[string]interface{}{
+		{"ipv4addr": "10.0.0.20", "mac": "aa:bb:cc:dd:ee:ff", "allocate_from": nil},
+		{"ipv4addr": "10.3.0.1", "mac": "", "allocate_from": allocateFrom},
+		{"ipv4addr": "10.0.0.30", "mac": ""},
+	}
+	if !reflect.DeepEqual(expected, ipv4Addrs) {
+		t.Fatalf("Expected the allocated address to stay with its allocate_from, got %v", ipv4Addrs)
+	}
+
+	// once read back the allocated address is matched by address
+	ipv4Addrs = flattenHostIPv4Addrs(IBXIPv4Addrs, []interface{}{
+		map[string]interface{}{"ipv4addr": "10.0.0.20", "mac": "aa:bb:cc:dd:ee:ff"},
+		map[string]interface{}{"ipv4addr": "10.3.0.1", "allocate_from": allocateFrom},
+		map[string]interface{}{"ipv4addr": "10.0.0.30"},
+	})
+	if !reflect.DeepEqual(expected[:2], ipv4Addrs[:2]) || ipv4Addrs[2]["ipv4addr"] != "10.0.0.30" {
+		t.Fatalf("Expected the addresses in the order of the state, got %v", ipv4Addrs)
+	}
+}
+
+func testAccResourceHostRecordDestroy(state *terraform.State) error {
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "infoblox_host_record" {
+			continue
+		}
+		api := hostrecord.NewGet(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusNotFound {
+			return fmt.Errorf("Host record %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccResourceHostRecordExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("\nInfoblox Host record resource %s not found in resources", resourceName)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("\nInfoblox Host record resource %s ID not set", resourceName)
+		}
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
+		api := hostrecord.NewGet(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Could not find %s", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccResourceHostRecordCreateTemplate(hostName, networkAddr string) string {
+	return fmt.Sprintf(`
+resource "infoblox_network" "acctest" {
+  network = "%s"
+}
+
+resource "infoblox_host_record" "acctest" {
+  name = "%s"
+  comment = "a host record"
+  aliases = ["alias-%s"]
+  ipv4addrs {
+    ipv4addr = "10.0.0.20"
+    mac = "aa:bb:cc:dd:ee:ff"
+  }
+  ipv4addrs {
+    allocate_from {
+      network = "${infoblox_network.acctest.network}"
+    }
+  }
+}`, networkAddr, hostName, hostName)
+}
+
+func testAccResourceHostRecordUpdateTemplate(hostName, networkAddr string) string {
+	return fmt.Sprintf(`
+resource "infoblox_network" "acctest" {
+  network = "%s"
+}
+
+resource "infoblox_host_record" "acctest" {
+  name = "%s"
+  comment = "an updated host record"
+  ipv4addrs {
+    ipv4addr = "10.0.0.20"
+    mac = "aa:bb:cc:dd:ee:ff"
+  }
+  ipv4addrs {
+    allocate_from {
+      network = "${infoblox_network.acctest.network}"
+    }
+  }
+}`, networkAddr, hostName)
+}
+
+func testAccResourceHostRecordNoCommentTemplate(hostName, networkAddr string) string {
+	return fmt.Sprintf(`
+resource "infoblox_network" "acctest" {
+  network = "%s"
+}
+
+resource "infoblox_host_record" "acctest" {
+  name = "%s"
+  ipv4addrs {
+    ipv4addr = "10.0.0.20"
+    mac = "aa:bb:cc:dd:ee:ff"
+  }
+  ipv4addrs {
+    allocate_from {
+      network = "${infoblox_network.acctest.network}"
+    }
+  }
+}`, networkAddr, hostName)
+}
@@ -0,0 +1,36 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+)
+
+// importStateFunc - returns an import function for objects of the given WAPI type.
+// The import ID can either be the WAPI reference of the object or a friendly key made of the
+// values of keyFields separated by "/", e.g. example.com/default for a zone_auth keyed by fqdn and view.
+func importStateFunc(objectType string, keyFields ...string) schema.StateFunc {
+	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		if util.IsReference(objectType, d.Id()) {
+			return []*schema.ResourceData{d}, nil
+		}
+		if len(keyFields) == 0 {
+			return nil, fmt.Errorf("Infoblox Import Error: %s objects can only be imported by reference", objectType)
+		}
+
+		searchFields, err := util.ParseImportKey(d.Id(), keyFields)
+		if err != nil {
+			return nil, fmt.Errorf("Infoblox Import Error: %s", err)
+		}
+
+		infobloxClient := m.(*providerMeta).client
+		objects := new([]map[string]interface{})
+		err = searchSingleObject(infobloxClient, objectType, searchFields, nil, objects)
+		if err != nil {
+			return nil, fmt.Errorf("Infoblox Import Error: %s", err)
+		}
+
+		d.SetId((*objects)[0]["_ref"].(string))
+		return []*schema.ResourceData{d}, nil
+	}
+}
@@ -0,0 +1,208 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/skyinfoblox/api/records"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+	"net/http"
+)
+
+func resourceMXRecord() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceMXRecordCreate,
+		Read:   resourceMXRecordRead,
+		Update: resourceMXRecordUpdate,
+		Delete: resourceMXRecordDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("record:mx", "name", "view"),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "name for the MX record",
+				ValidateFunc: util.CheckLeadingTrailingSpaces,
+			},
+			"mail_exchanger": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The FQDN of the mail exchanger",
+				ValidateFunc: util.CheckLeadingTrailingSpaces,
+			},
+			"preference": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				Description:  "The preference of the mail exchanger, lower values are preferred",
+				ValidateFunc: util.ValidateUnsignedInteger,
+			},
+			"view": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The DNS view in which the record resides",
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "DNS Zone for the record",
+			},
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "TTL in seconds for the record",
+				ValidateFunc: util.ValidateUnsignedInteger,
+			},
+			"use_ttl": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ref": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Internal Reference for the record",
+			},
+			"extattrs": util.ExtAttrsSchema(),
+		},
+	}
+}
+
+func resourceMXRecordCreate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	var createMXRecord records.MXRecord
+
+	createMXRecord.Name = d.Get("name").(string)
+	createMXRecord.MailExchanger = d.Get("mail_exchanger").(string)
+	preference := d.Get("preference").(int)
+	createMXRecord.Preference = &preference
+	if v, ok := d.GetOk("view"); ok {
+		createMXRecord.View = v.(string)
+	}
+	if v, ok := d.GetOk("ttl"); ok {
+		createMXRecord.TTL = uint(v.(int))
+	}
+	useTTL := d.Get("use_ttl").(bool)
+	createMXRecord.UseTTL = &useTTL
+	if v, ok := d.GetOk("comment"); ok {
+		comment := v.(string)
+		createMXRecord.Comment = &comment
+	}
+	createMXRecord.ExtAttrs = buildExtAttrs(d, m)
+
+	createAPI := records.NewCreateMXRecord(createMXRecord)
+	err := infobloxClient.Do(createAPI)
+	if err != nil {
+		return fmt.Errorf("Infoblox Create Error: %+v", err)
+	}
+	if skyinfoblox.IsConflict(createAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the MX record already exists, import it rather than creating it - %s", createAPI.Error())
+	}
+	if createAPI.StatusCode() != http.StatusCreated {
+		return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %d returned - response %s", createAPI.StatusCode(), createAPI.GetResponse())
+	}
+	d.SetId(createAPI.GetResponse())
+	return resourceMXRecordRead(d, m)
+}
+
+func resourceMXRecordRead(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	fields := []string{"name", "mail_exchanger", "preference", "view", "zone", "ttl", "use_ttl", "comment", "extattrs"}
+	getAPI := records.NewGetMXRecord(d.Id(), fields)
+	err := infobloxClient.Do(getAPI)
+	if err != nil {
+		return fmt.Errorf("Infoblox Read Error: %+v", err)
+	}
+	if skyinfoblox.IsNotFound(getAPI.Error()) {
+		d.SetId("")
+		return nil
+	}
+	if getAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %d returned - response %s", getAPI.StatusCode(), string(getAPI.RawResponse()))
+	}
+
+	record := getAPI.GetResponse()
+	d.SetId(record.Ref)
+	d.Set("name", record.Name)
+	d.Set("mail_exchanger", record.MailExchanger)
+	d.Set("preference", record.Preference)
+	d.Set("view", record.View)
+	d.Set("zone", record.Zone)
+	d.Set("ttl", record.TTL)
+	d.Set("use_ttl", record.UseTTL)
+	d.Set("comment", record.Comment)
+	d.Set("ref", record.Ref)
+	d.Set("extattrs", flattenExtAttrs(d, m, record.ExtAttrs))
+	return nil
+}
+
+func resourceMXRecordUpdate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	var updateMXRecord records.MXRecord
+	hasChanges := false
+
+	if d.HasChange("name") {
+		updateMXRecord.Name = d.Get("name").(string)
+		hasChanges = true
+	}
+	if d.HasChange("mail_exchanger") {
+		updateMXRecord.MailExchanger = d.Get("mail_exchanger").(string)
+		hasChanges = true
+	}
+	if d.HasChange("preference") {
+		preference := d.Get("preference").(int)
+		updateMXRecord.Preference = &preference
+		hasChanges = true
+	}
+	if d.HasChange("ttl") {
+		updateMXRecord.TTL = uint(d.Get("ttl").(int))
+		hasChanges = true
+	}
+	if d.HasChange("use_ttl") {
+		useTTL := d.Get("use_ttl").(bool)
+		updateMXRecord.UseTTL = &useTTL
+		hasChanges = true
+	}
+	if d.HasChange("comment") {
+		comment := d.Get("comment").(string)
+		updateMXRecord.Comment = &comment
+		hasChanges = true
+	}
+	if d.HasChange("extattrs") {
+		updateMXRecord.ExtAttrs = buildExtAttrs(d, m)
+		hasChanges = true
+	}
+
+	if hasChanges {
+		updateAPI := records.NewUpdateMXRecord(d.Id(), updateMXRecord)
+		err := infobloxClient.Do(updateAPI)
+		if err != nil {
+			return fmt.Errorf("Infoblox Update Error: %+v", err)
+		}
+		if updateAPI.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Infoblox Update Error: Invalid HTTP response code %d returned - response %s", updateAPI.StatusCode(), updateAPI.GetResponse())
+		}
+		d.SetId(updateAPI.GetResponse())
+	}
+	return resourceMXRecordRead(d, m)
+}
+
+func resourceMXRecordDelete(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	deleteAPI := records.NewDelete(d.Id())
+	err := infobloxClient.Do(deleteAPI)
+	if err != nil {
+		return fmt.Errorf("Infoblox Delete Error: %+v", err)
+	}
+	if deleteAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Delete Error: Invalid HTTP response code %d returned - response %s", deleteAPI.StatusCode(), deleteAPI.GetResponse())
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,117 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/sky-uk/skyinfoblox/api/records"
+	"net/http"
+	"testing"
+)
+
+func TestAccResourceMXRecord(t *testing.T) {
+
+	randInt := acctest.RandInt()
+	recordName := fmt.Sprintf("mx-record-test-%d.slupaas.bskyb.com", randInt)
+	resourceName := "infoblox_mx_record.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceMXRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceMXRecordCreateTemplate(recordName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceMXRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", recordName),
+					resource.TestCheckResourceAttr(resourceName, "mail_exchanger", "mail1.slupaas.bskyb.com"),
+					resource.TestCheckResourceAttr(resourceName, "preference", "10"),
+				),
+			},
+			{
+				Config: testAccResourceMXRecordUpdateTemplate(recordName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceMXRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", recordName),
+					resource.TestCheckResourceAttr(resourceName, "mail_exchanger", "mail2.slupaas.bskyb.com"),
+					resource.TestCheckResourceAttr(resourceName, "preference", "20"),
+					resource.TestCheckResourceAttr(resourceName, "comment", "an updated MX record"),
+				),
+			},
+			{
+				Config:            testAccResourceMXRecordUpdateTemplate(recordName),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccResourceMXRecordCreateTemplate(recordName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceMXRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "comment", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceMXRecordDestroy(state *terraform.State) error {
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "infoblox_mx_record" {
+			continue
+		}
+		api := records.NewGetMXRecord(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusNotFound {
+			return fmt.Errorf("MX record %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccResourceMXRecordExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("\nInfoblox MX record resource %s not found in resources", resourceName)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("\nInfoblox MX record resource %s ID not set", resourceName)
+		}
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
+		api := records.NewGetMXRecord(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Could not find %s", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccResourceMXRecordCreateTemplate(recordName string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_mx_record" "acctest" {
+	name = "%s"
+	mail_exchanger = "mail1.slupaas.bskyb.com"
+	preference = 10
+	}`, recordName)
+}
+
+func testAccResourceMXRecordUpdateTemplate(recordName string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_mx_record" "acctest" {
+	name = "%s"
+	mail_exchanger = "mail2.slupaas.bskyb.com"
+	preference = 20
+	comment = "an updated MX record"
+	}`, recordName)
+}
@@ -0,0 +1,169 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/skyinfoblox/api/namedacl"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+	"net/http"
+)
+
+func resourceNamedACL() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNamedACLCreate,
+		Read:   resourceNamedACLRead,
+		Update: resourceNamedACLUpdate,
+		Delete: resourceNamedACLDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("namedacl", "name"),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the named ACL",
+				ValidateFunc: util.CheckLeadingTrailingSpaces,
+			},
+			"comment": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Comment for the named ACL; maximum 256 characters",
+				ValidateFunc: util.CheckLeadingTrailingSpaces,
+			},
+			"access_list": util.AccessControlSchema(),
+			"extattrs":    util.ExtAttrsSchema(),
+		},
+	}
+}
+
+// buildNamedACLAccessList - builds the access list of a named ACL, which only holds addresses, networks and TSIG keys
+func buildNamedACLAccessList(acList []interface{}) (*[]interface{}, error) {
+	accessList := util.BuildAcList(acList)
+	for _, value := range accessList {
+		if name, ok := value.(util.NamedACL); ok {
+			return nil, fmt.Errorf("Infoblox Named ACL access_list can't reference the named ACL %s, only addressac and tsigac entries are allowed", name)
+		}
+	}
+	return &accessList, nil
+}
+
+// resourceNamedACLCreate - Creates a new named ACL
+func resourceNamedACLCreate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	var namedACLCreate namedacl.NamedACL
+	var err error
+
+	namedACLCreate.Name = d.Get("name").(string)
+	if v, ok := d.GetOk("comment"); ok {
+		comment := v.(string)
+		namedACLCreate.Comment = &comment
+	}
+	if v, ok := d.GetOk("access_list"); ok {
+		if namedACLCreate.AccessList, err = buildNamedACLAccessList(v.([]interface{})); err != nil {
+			return err
+		}
+	}
+	namedACLCreate.ExtAttrs = buildExtAttrs(d, m)
+
+	createAPI := namedacl.NewCreate(namedACLCreate)
+	err = infobloxClient.Do(createAPI)
+	if err != nil {
+		return fmt.Errorf("Error creating the named ACL %s: %s", namedACLCreate.Name, err)
+	}
+	if skyinfoblox.IsConflict(createAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the named ACL already exists, import it rather than creating it - %s", createAPI.Error())
+	}
+	if createAPI.StatusCode() != http.StatusCreated {
+		return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %d returned - response %s", createAPI.StatusCode(), string(createAPI.RawResponse()))
+	}
+	d.SetId(*createAPI.ResponseObject().(*string))
+	return resourceNamedACLRead(d, m)
+}
+
+// resourceNamedACLRead - Reads the resource
+func resourceNamedACLRead(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	getAPI := namedacl.NewGet(d.Id(), []string{"name", "comment", "access_list", "extattrs"})
+	err := infobloxClient.Do(getAPI)
+	if err != nil {
+		return fmt.Errorf("Could not read the named ACL %s", err)
+	}
+	if skyinfoblox.IsNotFound(getAPI.Error()) {
+		d.SetId("")
+		return nil
+	}
+	if getAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %d returned - response %s", getAPI.StatusCode(), string(getAPI.RawResponse()))
+	}
+
+	namedACL := getAPI.ResponseObject().(*namedacl.NamedACL)
+	d.SetId(namedACL.Ref)
+	d.Set("name", namedACL.Name)
+	d.Set("comment", namedACL.Comment)
+	if namedACL.AccessList != nil {
+		d.Set("access_list", util.BuildAcListFromIBX(*namedACL.AccessList))
+	} else {
+		d.Set("access_list", []map[string]interface{}{})
+	}
+	d.Set("extattrs", flattenExtAttrs(d, m, namedACL.ExtAttrs))
+	return nil
+}
+
+// resourceNamedACLUpdate - Updates the resource
+func resourceNamedACLUpdate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	hasChanges := false
+	var updateNamedACL namedacl.NamedACL
+	var err error
+	updateNamedACL.Ref = d.Id()
+
+	if d.HasChange("name") {
+		updateNamedACL.Name = d.Get("name").(string)
+		hasChanges = true
+	}
+	if d.HasChange("comment") {
+		comment := d.Get("comment").(string)
+		updateNamedACL.Comment = &comment
+		hasChanges = true
+	}
+	if d.HasChange("access_list") {
+		if updateNamedACL.AccessList, err = buildNamedACLAccessList(d.Get("access_list").([]interface{})); err != nil {
+			return err
+		}
+		hasChanges = true
+	}
+	if d.HasChange("extattrs") {
+		updateNamedACL.ExtAttrs = buildExtAttrs(d, m)
+		hasChanges = true
+	}
+
+	if hasChanges {
+		updateAPI := namedacl.NewUpdate(updateNamedACL)
+		err = infobloxClient.Do(updateAPI)
+		if err != nil {
+			return fmt.Errorf("Error updating the named ACL %s", err)
+		}
+		if updateAPI.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Infoblox Update Error: Invalid HTTP response code %d returned - response %s", updateAPI.StatusCode(), string(updateAPI.RawResponse()))
+		}
+		d.SetId(*updateAPI.ResponseObject().(*string))
+	}
+	return resourceNamedACLRead(d, m)
+}
+
+// resourceNamedACLDelete - Deletes the resource
+func resourceNamedACLDelete(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	deleteAPI := namedacl.NewDelete(d.Id())
+	err := infobloxClient.Do(deleteAPI)
+	if err != nil {
+		return fmt.Errorf("Could not delete the named ACL %s", err)
+	}
+	if deleteAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Delete Error: Invalid HTTP response code %d returned - response %s", deleteAPI.StatusCode(), string(deleteAPI.RawResponse()))
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,154 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/sky-uk/skyinfoblox/api/namedacl"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func TestAccResourceNamedACL(t *testing.T) {
+	name := fmt.Sprintf("acctest-named-acl-%d", acctest.RandInt())
+	resourceName := "infoblox_named_acl.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceNamedACLDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceNamedACLNestedTemplate(name),
+				ExpectError: regexp.MustCompile(`only addressac and tsigac entries are allowed`),
+			},
+			{
+				Config: testAccResourceNamedACLCreateTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceNamedACLExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "comment", "secondary servers"),
+					resource.TestCheckResourceAttr(resourceName, "access_list.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "access_list.0.type", "addressac"),
+					resource.TestCheckResourceAttr(resourceName, "access_list.0.address", "10.10.0.0/16"),
+					resource.TestCheckResourceAttr(resourceName, "access_list.0.permission", "ALLOW"),
+					resource.TestCheckResourceAttr(resourceName, "access_list.1.type", "tsigac"),
+					resource.TestCheckResourceAttr(resourceName, "access_list.1.tsig_key_name", "transfer.key"),
+				),
+			},
+			{
+				Config: testAccResourceNamedACLUpdateTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceNamedACLExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "comment", "secondary servers - updated"),
+					resource.TestCheckResourceAttr(resourceName, "access_list.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "access_list.0.address", "10.20.0.1"),
+					resource.TestCheckResourceAttr(resourceName, "access_list.0.permission", "DENY"),
+					resource.TestCheckResourceAttr(resourceName, "access_list.1.address", "10.10.0.0/16"),
+					resource.TestCheckResourceAttr(resourceName, "extattrs.%", "1"),
+				),
+			},
+			{
+				Config:            testAccResourceNamedACLUpdateTemplate(name),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     name,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceNamedACLDestroy(state *terraform.State) error {
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "infoblox_named_acl" {
+			continue
+		}
+		api := namedacl.NewGet(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusNotFound {
+			return fmt.Errorf("Named ACL %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccResourceNamedACLExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("\nInfoblox named ACL resource %s not found in resources", resourceName)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("\nInfoblox named ACL resource %s ID not set", resourceName)
+		}
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
+		api := namedacl.NewGet(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Could not find %s", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccResourceNamedACLNestedTemplate(name string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_named_acl" "acctest" {
+	name = "%s"
+	access_list {
+	  type = "namedacl"
+	  name = "another-acl"
+	}
+	}`, name)
+}
+
+func testAccResourceNamedACLCreateTemplate(name string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_named_acl" "acctest" {
+	name = "%s"
+	comment = "secondary servers"
+	access_list {
+	  type = "addressac"
+	  address = "10.10.0.0/16"
+	  permission = "ALLOW"
+	}
+	access_list {
+	  type = "tsigac"
+	  tsig_key = "0jnu3SdsMvzzlmTDPYRceA=="
+	  tsig_key_alg = "HMAC-SHA256"
+	  tsig_key_name = "transfer.key"
+	  use_tsig_key_name = false
+	}
+	}`, name)
+}
+
+func testAccResourceNamedACLUpdateTemplate(name string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_named_acl" "acctest" {
+	name = "%s"
+	comment = "secondary servers - updated"
+	access_list {
+	  type = "addressac"
+	  address = "10.20.0.1"
+	  permission = "DENY"
+	}
+	access_list {
+	  type = "addressac"
+	  address = "10.10.0.0/16"
+	  permission = "ALLOW"
+	}
+	extattrs {
+	  Site = "London"
+	}
+	}`, name)
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/skyinfoblox/api"
 	"github.com/sky-uk/skyinfoblox/api/network"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
 	"net/http"
 )
 
@@ -14,6 +16,9 @@ func resourceNetwork() *schema.Resource {
 		Read:   resourceNetworkRead,
 		Update: resourceNetworkUpdate,
 		Delete: resourceNetworkDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("network", "network", "network_view"),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"ref": {
@@ -22,13 +27,44 @@ func resourceNetwork() *schema.Resource {
 				Description: "Unique reference to Infoblox Network resource",
 			},
 			"network": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"allocate_from"},
+			},
+			"allocate_from": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				Description:   "Lets the grid carve the next available network out of a network container instead of setting the network",
+				ConflictsWith: []string{"network"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_container": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The network container to carve the network from, in CIDR format",
+						},
+						"prefix_length": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Number of bits in the netmask of the new network",
+						},
+						"exclude": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Networks which must not be allocated, in CIDR format",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
 			},
 			"networkview": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
 			"comment": {
 				Type:     schema.TypeString,
@@ -62,18 +98,22 @@ func resourceNetwork() *schema.Resource {
 			"high_watermark": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
 			},
 			"high_watermark_reset": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
 			},
 			"low_watermark": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
 			},
 			"low_watermark_reset": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
 			},
 			"enabledhcpthresholds": {
 				Type:     schema.TypeBool,
@@ -103,6 +143,7 @@ func resourceNetwork() *schema.Resource {
 			"leasescavengetime": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
 			},
 			"members": {
 				Type:        schema.TypeList,
@@ -137,42 +178,9 @@ func resourceNetwork() *schema.Resource {
 			"networkcontainer": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
-			"option": &schema.Schema{
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Description: "DHCP Related] Options such as DNS servers, gateway, ntp, etc",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:        schema.TypeString,
-							Description: "DHCP Option Name",
-							Optional:    true,
-						},
-						"num": {
-							Type:        schema.TypeInt,
-							Description: "DHCO Option number",
-							Optional:    true,
-						},
-						"useoption": {
-							Type:        schema.TypeBool,
-							Description: "Use the option or not",
-							Optional:    true,
-						},
-						"value": {
-							Type:        schema.TypeString,
-							Description: "Value of the option",
-							Optional:    true,
-						},
-						"vendorclass": {
-							Type:        schema.TypeString,
-							Description: "Vendor Class",
-							Default:     "DHCP",
-							Optional:    true,
-						},
-					},
-				},
-			},
+			"option": dhcpOptionsSchema(),
 			"use_options": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -180,6 +188,7 @@ func resourceNetwork() *schema.Resource {
 			"recycleleases": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
 			},
 			"use_recycleleases": {
 				Type:     schema.TypeBool,
@@ -193,18 +202,26 @@ func resourceNetwork() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"extattrs":                    util.ExtAttrsSchema(),
+			"inherited_extattrs":          util.InheritedExtAttrsSchema(),
+			"extattrs_descendants_action": util.ExtAttrsDescendantsActionSchema(),
 		},
 	}
 }
 
 // resourceNetworkCreate  - Creates a new netowrk resource
 func resourceNetworkCreate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var networkCreate network.Network
 	var authority, useAuthority, createReverseZone, networkDisable, enableDdns, useEnableDdns, enableDhcpThresholds, useEnableDhcpThresholds, enableDiscovery, useEnableDiscovery, recycleLeases, useRecycleLeases, useOptions bool
 
+	var nextAvailableNetwork *api.ObjectFunction
 	if v, ok := d.GetOk("network"); ok {
 		networkCreate.Network = v.(string)
+	} else if v, ok := d.GetOk("allocate_from"); ok {
+		nextAvailableNetwork = buildNextAvailableNetwork(v.([]interface{})[0].(map[string]interface{}), d.Get("networkview").(string))
+	} else {
+		return fmt.Errorf("one of network or allocate_from is required")
 	}
 	if v, ok := d.GetOk("networkview"); ok {
 		networkCreate.NetworkView = v.(string)
@@ -302,13 +319,20 @@ func resourceNetworkCreate(d *schema.ResourceData, m interface{}) error {
 		useRecycleLeases = v.(bool)
 		networkCreate.UseRecycleLeases = &useRecycleLeases
 	}
+	networkCreate.ExtAttrs = buildInheritableExtAttrs(d, m)
 
 	createNetworkAPI := network.NewCreateNetwork(networkCreate)
+	if nextAvailableNetwork != nil {
+		createNetworkAPI = network.NewCreateNetworkNextAvailable(networkCreate, nextAvailableNetwork)
+	}
 	createNetworkError := infobloxClient.Do(createNetworkAPI)
 	if createNetworkError != nil {
 		return fmt.Errorf("Error Creating Network %s", createNetworkError)
 	}
 
+	if skyinfoblox.IsConflict(createNetworkAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the network already exists, import it rather than creating it - %s", createNetworkAPI.Error())
+	}
 	if createNetworkAPI.StatusCode() != http.StatusCreated {
 		return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %+v returned. Response object was %+v", createNetworkAPI.StatusCode(), createNetworkAPI.GetResponse())
 	}
@@ -318,7 +342,7 @@ func resourceNetworkCreate(d *schema.ResourceData, m interface{}) error {
 
 // resourceNetworkDelete  - Delete a network resource
 func resourceNetworkDelete(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	deleteAPI := network.NewDeleteNetwork(d.Id())
 	deleteErr := infobloxClient.Do(deleteAPI)
 	if deleteErr != nil {
@@ -333,29 +357,60 @@ func resourceNetworkDelete(d *schema.ResourceData, m interface{}) error {
 
 // resourceNetworkRead - Reads the resource
 func resourceNetworkRead(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
-	fields := []string{"ipv4addr", "disable", "network", "network_view", "comment", "netmask", "members", "authority", "enable_ddns", "options"}
+	infobloxClient := m.(*providerMeta).client
+	fields := []string{"network", "network_view", "comment", "authority", "use_authority", "disable", "enable_ddns", "use_enable_ddns",
+		"high_water_mark", "high_water_mark_reset", "low_water_mark", "low_water_mark_reset", "enable_dhcp_thresholds", "use_enable_dhcp_thresholds",
+		"enable_discovery", "use_enable_discovery", "discovery_member", "ipv4addr", "lease_scavenge_time", "netmask", "members", "network_container",
+		"options", "use_options", "recycle_leases", "use_recycle_leases", "update_dns_on_lease_renewal", "extattrs"}
+	fields, err := supportedFields(m, "network", fields)
+	if err != nil {
+		return err
+	}
 	getNetworkAPI := network.NewGetNetwork(d.Id(), fields)
 	networkReadErr := infobloxClient.Do(getNetworkAPI)
 	if networkReadErr != nil {
 		return fmt.Errorf("Could not read resource %s", networkReadErr)
 	}
+	if skyinfoblox.IsNotFound(getNetworkAPI.Error()) {
+		d.SetId("")
+		return nil
+	}
 
 	if getNetworkAPI.StatusCode() != http.StatusOK {
-		return fmt.Errorf("Http Error Reading the resource: %s", getNetworkAPI.ResponseObject())
+		return fmt.Errorf("Http Error Reading the resource: %s", getNetworkAPI.Error())
 	}
 
 	readNetwork := getNetworkAPI.GetResponse()
+	d.SetId(readNetwork.Ref)
 	d.Set("network", readNetwork.Network)
-	d.Set("ipv4addr", readNetwork.Ipv4addr)
-	d.Set("netmask", readNetwork.Netmask)
-	d.Set("disable", readNetwork.Disable)
-	d.Set("options", readNetwork.Options)
-	d.Set("members", readNetwork.Members)
+	d.Set("networkview", readNetwork.NetworkView)
 	d.Set("comment", readNetwork.Comment)
 	d.Set("authority", readNetwork.Authority)
-	d.Set("enable_ddns", readNetwork.EnableDdns)
-	d.Set("network_view", readNetwork.NetworkView)
+	d.Set("use_authority", readNetwork.UseAuthority)
+	d.Set("disable", readNetwork.Disable)
+	d.Set("enableddns", readNetwork.EnableDdns)
+	d.Set("use_enableddns", readNetwork.UseEnableDdns)
+	d.Set("high_watermark", readNetwork.HighWaterMark)
+	d.Set("high_watermark_reset", readNetwork.HighWaterMarkReset)
+	d.Set("low_watermark", readNetwork.LowWaterMark)
+	d.Set("low_watermark_reset", readNetwork.LowWaterMarkReset)
+	d.Set("enabledhcpthresholds", readNetwork.EnableDhcpThresholds)
+	d.Set("use_enabledhcpthresholds", readNetwork.UseEnableDhcpThresholds)
+	d.Set("enablediscovery", readNetwork.EnableDiscovery)
+	d.Set("use_enablediscovery", readNetwork.UseEnableDiscovery)
+	d.Set("discovery_member", readNetwork.DiscoveryMember)
+	d.Set("ipv4addr", readNetwork.Ipv4addr)
+	d.Set("leasescavengetime", readNetwork.LeaseScavengeTime)
+	d.Set("netmask", readNetwork.Netmask)
+	d.Set("members", flattenNetworkMembersList(readNetwork.Members))
+	d.Set("networkcontainer", readNetwork.NetworkContainer)
+	d.Set("option", flattenOptionsObject(readNetwork.Options))
+	d.Set("use_options", readNetwork.UseOptions)
+	d.Set("recycleleases", readNetwork.RecycleLeases)
+	d.Set("use_recycleleases", readNetwork.UseRecycleLeases)
+	d.Set("updatednsonleaserenewal", readNetwork.UpdateDNSOnLeaseRenewal)
+	d.Set("extattrs", flattenExtAttrs(d, m, readNetwork.ExtAttrs))
+	d.Set("inherited_extattrs", util.BuildInheritedExtAttrsFromIBX(readNetwork.ExtAttrs))
 	d.Set("ref", readNetwork.Ref)
 
 	return nil
@@ -363,7 +418,7 @@ func resourceNetworkRead(d *schema.ResourceData, m interface{}) error {
 
 // resourceNetworkUpdate - Updates the resource
 func resourceNetworkUpdate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	hasChanges := false
 	var updateNetwork network.Network
 	if v, ok := d.GetOk("ref"); ok {
@@ -497,6 +552,10 @@ func resourceNetworkUpdate(d *schema.ResourceData, m interface{}) error {
 		}
 		hasChanges = true
 	}
+	if d.HasChange("extattrs") {
+		updateNetwork.ExtAttrs = buildInheritableExtAttrs(d, m)
+		hasChanges = true
+	}
 
 	if hasChanges {
 		updateNetworkAPI := network.NewUpdateNetwork(updateNetwork)
@@ -511,6 +570,46 @@ func resourceNetworkUpdate(d *schema.ResourceData, m interface{}) error {
 	return resourceNetworkRead(d, m)
 }
 
+// dhcpOptionsSchema - returns the schema for the DHCP options of a network or network container
+func dhcpOptionsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Computed:    true,
+		Description: "DHCP Related] Options such as DNS servers, gateway, ntp, etc",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Description: "DHCP Option Name",
+					Optional:    true,
+				},
+				"num": {
+					Type:        schema.TypeInt,
+					Description: "DHCO Option number",
+					Optional:    true,
+				},
+				"useoption": {
+					Type:        schema.TypeBool,
+					Description: "Use the option or not",
+					Optional:    true,
+				},
+				"value": {
+					Type:        schema.TypeString,
+					Description: "Value of the option",
+					Optional:    true,
+				},
+				"vendorclass": {
+					Type:        schema.TypeString,
+					Description: "Vendor Class",
+					Default:     "DHCP",
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
 // buildOptionsObject - This is to avoid having to repeat the code every time I need to read this field
 func buildOptionsObject(options *schema.Set) []network.DHCPOptions {
 	optionValues := []network.DHCPOptions{}
@@ -558,3 +657,52 @@ func buildNetworkMembersList(membersList []interface{}) []network.Member {
 	}
 	return members
 }
+
+// flattenOptionsObject - the reverse of buildOptionsObject, turns the DHCP options read from Infoblox into the option set
+func flattenOptionsObject(options []network.DHCPOptions) []map[string]interface{} {
+	optionValues := make([]map[string]interface{}, 0)
+	for _, option := range options {
+		optionObject := make(map[string]interface{})
+		optionObject["name"] = option.Name
+		optionObject["num"] = int(option.Num)
+		if option.UseOption != nil {
+			optionObject["useoption"] = *option.UseOption
+		}
+		optionObject["value"] = option.Value
+		optionObject["vendorclass"] = option.VendorClass
+		optionValues = append(optionValues, optionObject)
+	}
+	return optionValues
+}
+
+// flattenNetworkMembersList - the reverse of buildNetworkMembersList
+func flattenNetworkMembersList(members []network.Member) []map[string]interface{} {
+	membersList := make([]map[string]interface{}, 0)
+	for _, member := range members {
+		memberObj := make(map[string]interface{})
+		memberObj["ipv4addr"] = member.IPv4Address
+		memberObj["ipv6addr"] = member.IPv6Address
+		memberObj["name"] = member.Name
+		membersList = append(membersList, memberObj)
+	}
+	return membersList
+}
+
+// buildNextAvailableNetwork - builds the func:nextavailablenetwork object function for an allocate_from block
+func buildNextAvailableNetwork(allocateFrom map[string]interface{}, networkView string) *api.ObjectFunction {
+	if networkView == "" {
+		networkView = "default"
+	}
+	objectParameters := map[string]string{
+		"network":      allocateFrom["network_container"].(string),
+		"network_view": networkView,
+	}
+
+	var exclude []string
+	if v, ok := allocateFrom["exclude"].([]interface{}); ok {
+		for _, excludedNetwork := range v {
+			exclude = append(exclude, excludedNetwork.(string))
+		}
+	}
+	return api.NewNextAvailableNetwork(objectParameters, allocateFrom["prefix_length"].(int), exclude)
+}
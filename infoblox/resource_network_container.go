@@ -0,0 +1,289 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/skyinfoblox/api/networkcontainer"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+	"net/http"
+)
+
+func resourceNetworkContainer() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetworkContainerCreate,
+		Read:   resourceNetworkContainerRead,
+		Update: resourceNetworkContainerUpdate,
+		Delete: resourceNetworkContainerDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("networkcontainer", "network", "network_view"),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"ref": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique reference to Infoblox Network Container resource",
+			},
+			"network": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The network address of the container in CIDR format",
+			},
+			"networkview": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"authority": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"use_authority": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"enableddns": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"use_enableddns": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"enablediscovery": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"use_enablediscovery": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"discovery_member": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"networkcontainer": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The parent network container of this container",
+			},
+			"option":                      dhcpOptionsSchema(),
+			"extattrs":                    util.ExtAttrsSchema(),
+			"inherited_extattrs":          util.InheritedExtAttrsSchema(),
+			"extattrs_descendants_action": util.ExtAttrsDescendantsActionSchema(),
+			"use_options": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"remove_subnets": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the networks and containers below this container when it is deleted, otherwise they are moved up to the parent",
+			},
+		},
+	}
+}
+
+// resourceNetworkContainerCreate - Creates a new network container resource
+func resourceNetworkContainerCreate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	var containerCreate networkcontainer.NetworkContainer
+
+	containerCreate.Network = d.Get("network").(string)
+	if v, ok := d.GetOk("networkview"); ok {
+		containerCreate.NetworkView = v.(string)
+	}
+	if v, ok := d.GetOk("comment"); ok {
+		comment := v.(string)
+		containerCreate.Comment = &comment
+	}
+	if v, ok := d.GetOk("authority"); ok {
+		authority := v.(bool)
+		containerCreate.Authority = &authority
+	}
+	if v, ok := d.GetOk("use_authority"); ok {
+		useAuthority := v.(bool)
+		containerCreate.UseAuthority = &useAuthority
+	}
+	if v, ok := d.GetOk("enableddns"); ok {
+		enableDdns := v.(bool)
+		containerCreate.EnableDdns = &enableDdns
+	}
+	if v, ok := d.GetOk("use_enableddns"); ok {
+		useEnableDdns := v.(bool)
+		containerCreate.UseEnableDdns = &useEnableDdns
+	}
+	if v, ok := d.GetOk("enablediscovery"); ok {
+		enableDiscovery := v.(bool)
+		containerCreate.EnableDiscovery = &enableDiscovery
+	}
+	if v, ok := d.GetOk("use_enablediscovery"); ok {
+		useEnableDiscovery := v.(bool)
+		containerCreate.UseEnableDiscovery = &useEnableDiscovery
+	}
+	if v, ok := d.GetOk("discovery_member"); ok {
+		containerCreate.DiscoveryMember = v.(string)
+	}
+	if v, ok := d.GetOk("option"); ok {
+		containerCreate.Options = buildOptionsObject(v.(*schema.Set))
+	}
+	if v, ok := d.GetOk("use_options"); ok {
+		useOptions := v.(bool)
+		containerCreate.UseOptions = &useOptions
+	}
+	containerCreate.ExtAttrs = buildInheritableExtAttrs(d, m)
+
+	createAPI := networkcontainer.NewCreate(containerCreate)
+	err := infobloxClient.Do(createAPI)
+	if err != nil {
+		return fmt.Errorf("Error Creating Network Container %s", err)
+	}
+	if skyinfoblox.IsConflict(createAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the network container already exists, import it rather than creating it - %s", createAPI.Error())
+	}
+	if createAPI.StatusCode() != http.StatusCreated {
+		return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %d returned - response %s", createAPI.StatusCode(), *createAPI.ResponseObject().(*string))
+	}
+	d.SetId(*createAPI.ResponseObject().(*string))
+	return resourceNetworkContainerRead(d, m)
+}
+
+// resourceNetworkContainerRead - Reads the resource
+func resourceNetworkContainerRead(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	fields := []string{"network", "network_view", "network_container", "comment", "authority", "use_authority", "enable_ddns", "use_enable_ddns",
+		"enable_discovery", "use_enable_discovery", "discovery_member", "options", "use_options", "extattrs"}
+	fields, err := supportedFields(m, networkcontainer.Endpoint, fields)
+	if err != nil {
+		return err
+	}
+	getAPI := networkcontainer.NewGet(d.Id(), fields)
+	err = infobloxClient.Do(getAPI)
+	if err != nil {
+		return fmt.Errorf("Could not read resource %s", err)
+	}
+	if skyinfoblox.IsNotFound(getAPI.Error()) {
+		d.SetId("")
+		return nil
+	}
+	if getAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %d returned - response %s", getAPI.StatusCode(), string(getAPI.RawResponse()))
+	}
+
+	container := getAPI.ResponseObject().(*networkcontainer.NetworkContainer)
+	d.SetId(container.Ref)
+	d.Set("ref", container.Ref)
+	d.Set("network", container.Network)
+	d.Set("networkview", container.NetworkView)
+	d.Set("networkcontainer", container.NetworkContainer)
+	d.Set("comment", container.Comment)
+	d.Set("authority", container.Authority)
+	d.Set("use_authority", container.UseAuthority)
+	d.Set("enableddns", container.EnableDdns)
+	d.Set("use_enableddns", container.UseEnableDdns)
+	d.Set("enablediscovery", container.EnableDiscovery)
+	d.Set("use_enablediscovery", container.UseEnableDiscovery)
+	d.Set("discovery_member", container.DiscoveryMember)
+	d.Set("option", flattenOptionsObject(container.Options))
+	d.Set("use_options", container.UseOptions)
+	d.Set("extattrs", flattenExtAttrs(d, m, container.ExtAttrs))
+	d.Set("inherited_extattrs", util.BuildInheritedExtAttrsFromIBX(container.ExtAttrs))
+	return nil
+}
+
+// resourceNetworkContainerUpdate - Updates the resource
+func resourceNetworkContainerUpdate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	hasChanges := false
+	var updateContainer networkcontainer.NetworkContainer
+	updateContainer.Ref = d.Id()
+
+	if d.HasChange("comment") {
+		comment := d.Get("comment").(string)
+		updateContainer.Comment = &comment
+		hasChanges = true
+	}
+	if d.HasChange("authority") {
+		authority := d.Get("authority").(bool)
+		updateContainer.Authority = &authority
+		hasChanges = true
+	}
+	if d.HasChange("use_authority") {
+		useAuthority := d.Get("use_authority").(bool)
+		updateContainer.UseAuthority = &useAuthority
+		hasChanges = true
+	}
+	if d.HasChange("enableddns") {
+		enableDdns := d.Get("enableddns").(bool)
+		updateContainer.EnableDdns = &enableDdns
+		hasChanges = true
+	}
+	if d.HasChange("use_enableddns") {
+		useEnableDdns := d.Get("use_enableddns").(bool)
+		updateContainer.UseEnableDdns = &useEnableDdns
+		hasChanges = true
+	}
+	if d.HasChange("enablediscovery") {
+		enableDiscovery := d.Get("enablediscovery").(bool)
+		updateContainer.EnableDiscovery = &enableDiscovery
+		hasChanges = true
+	}
+	if d.HasChange("use_enablediscovery") {
+		useEnableDiscovery := d.Get("use_enablediscovery").(bool)
+		updateContainer.UseEnableDiscovery = &useEnableDiscovery
+		hasChanges = true
+	}
+	if d.HasChange("discovery_member") {
+		updateContainer.DiscoveryMember = d.Get("discovery_member").(string)
+		hasChanges = true
+	}
+	if d.HasChange("option") {
+		updateContainer.Options = buildOptionsObject(d.Get("option").(*schema.Set))
+		hasChanges = true
+	}
+	if d.HasChange("use_options") {
+		useOptions := d.Get("use_options").(bool)
+		updateContainer.UseOptions = &useOptions
+		hasChanges = true
+	}
+	if d.HasChange("extattrs") {
+		updateContainer.ExtAttrs = buildInheritableExtAttrs(d, m)
+		hasChanges = true
+	}
+
+	if hasChanges {
+		updateAPI := networkcontainer.NewUpdate(updateContainer)
+		err := infobloxClient.Do(updateAPI)
+		if err != nil {
+			return fmt.Errorf("Error updating the Network Container %s", err)
+		}
+		if updateAPI.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Infoblox Update Error: Invalid HTTP response code %d returned - response %s", updateAPI.StatusCode(), string(updateAPI.RawResponse()))
+		}
+		d.SetId(*updateAPI.ResponseObject().(*string))
+	}
+	return resourceNetworkContainerRead(d, m)
+}
+
+// resourceNetworkContainerDelete - Deletes the resource
+func resourceNetworkContainerDelete(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	deleteAPI := networkcontainer.NewDelete(d.Id(), d.Get("remove_subnets").(bool))
+	err := infobloxClient.Do(deleteAPI)
+	if err != nil {
+		return fmt.Errorf("Could not delete the Network Container %s", err)
+	}
+	if deleteAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Delete Error: Invalid HTTP response code %d returned - response %s", deleteAPI.StatusCode(), string(deleteAPI.RawResponse()))
+	}
+	d.SetId("")
+	return nil
+}
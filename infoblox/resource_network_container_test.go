@@ -0,0 +1,205 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/sky-uk/skyinfoblox/api/networkcontainer"
+	"net/http"
+	"testing"
+)
+
+func TestAccResourceNetworkContainer(t *testing.T) {
+	containerAddr := fmt.Sprintf("10.%d.0.0/16", acctest.RandIntRange(100, 200))
+	resourceName := "infoblox_network_container.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceNetworkContainerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceNetworkContainerCreateTemplate(containerAddr),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceNetworkContainerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "network", containerAddr),
+					resource.TestCheckResourceAttr(resourceName, "comment", "a network container"),
+					resource.TestCheckResourceAttr(resourceName, "networkview", "default"),
+					resource.TestCheckResourceAttrSet("infoblox_network.acctest", "network"),
+					resource.TestCheckResourceAttr("infoblox_network.acctest", "netmask", "24"),
+				),
+			},
+			{
+				Config: testAccResourceNetworkContainerUpdateTemplate(containerAddr),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceNetworkContainerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "network", containerAddr),
+					resource.TestCheckResourceAttr(resourceName, "comment", "an updated network container"),
+					resource.TestCheckResourceAttr(resourceName, "remove_subnets", "true"),
+				),
+			},
+			{
+				Config: testAccResourceNetworkContainerClearCommentTemplate(containerAddr),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceNetworkContainerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "comment", ""),
+				),
+			},
+			{
+				Config:                  testAccResourceNetworkContainerClearCommentTemplate(containerAddr),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           containerAddr + "/default",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"remove_subnets"},
+			},
+		},
+	})
+}
+
+func TestAccResourceNetworkContainerInheritedExtAttrs(t *testing.T) {
+	containerAddr := fmt.Sprintf("10.%d.0.0/16", acctest.RandIntRange(100, 200))
+	resourceName := "infoblox_network_container.acctest"
+	networkName := "infoblox_network.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourceNetworkContainerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceNetworkContainerExtAttrsTemplate(containerAddr, "network team"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceNetworkContainerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "extattrs.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "extattrs.Site", "London"),
+					resource.TestCheckResourceAttr(networkName, "extattrs.%", "1"),
+					resource.TestCheckResourceAttr(networkName, "extattrs.Owner", "network team"),
+					resource.TestCheckResourceAttr(networkName, "inherited_extattrs.Site", "London"),
+				),
+			},
+			{
+				Config: testAccResourceNetworkContainerExtAttrsTemplate(containerAddr, "platform team"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(networkName, "extattrs.Owner", "platform team"),
+					resource.TestCheckResourceAttr(networkName, "inherited_extattrs.Site", "London"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceNetworkContainerDestroy(state *terraform.State) error {
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "infoblox_network_container" {
+			continue
+		}
+		api := networkcontainer.NewGet(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusNotFound {
+			return fmt.Errorf("Network container %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccResourceNetworkContainerExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("\nInfoblox Network Container resource %s not found in resources", resourceName)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("\nInfoblox Network Container resource %s ID not set", resourceName)
+		}
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
+		api := networkcontainer.NewGet(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Could not find %s", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccResourceNetworkContainerCreateTemplate(containerAddr string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_network_container" "acctest" {
+	network = "%s"
+	comment = "a network container"
+	}
+
+	resource "infoblox_network" "acctest" {
+	comment = "a network carved from a container"
+	allocate_from {
+		network_container = "${infoblox_network_container.acctest.network}"
+		prefix_length = 24
+	}
+	}`, containerAddr)
+}
+
+func testAccResourceNetworkContainerUpdateTemplate(containerAddr string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_network_container" "acctest" {
+	network = "%s"
+	comment = "an updated network container"
+	remove_subnets = true
+	}
+
+	resource "infoblox_network" "acctest" {
+	comment = "a network carved from a container"
+	allocate_from {
+		network_container = "${infoblox_network_container.acctest.network}"
+		prefix_length = 24
+	}
+	}`, containerAddr)
+}
+
+func testAccResourceNetworkContainerClearCommentTemplate(containerAddr string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_network_container" "acctest" {
+	network = "%s"
+	remove_subnets = true
+	}
+
+	resource "infoblox_network" "acctest" {
+	comment = "a network carved from a container"
+	allocate_from {
+		network_container = "${infoblox_network_container.acctest.network}"
+		prefix_length = 24
+	}
+	}`, containerAddr)
+}
+
+func testAccResourceNetworkContainerExtAttrsTemplate(containerAddr, owner string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_network_container" "acctest" {
+	network = "%s"
+	remove_subnets = true
+	extattrs {
+		Site = "London"
+	}
+	extattrs_descendants_action {
+		option_with_ea = "RETAIN"
+		option_without_ea = "INHERIT"
+	}
+	}
+
+	resource "infoblox_network" "acctest" {
+	allocate_from {
+		network_container = "${infoblox_network_container.acctest.network}"
+		prefix_length = 24
+	}
+	extattrs {
+		Owner = "%s"
+	}
+	}`, containerAddr, owner)
+}
@@ -5,7 +5,6 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/network"
 	"strconv"
 	"testing"
@@ -34,14 +33,48 @@ func TestAccResourceNetwork(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "comment", "another comment on a network"),
 					resource.TestCheckResourceAttr(resourceName, "disable", "true"),
 				),
+			}, {
+				Config:                  testAccResourceNetworkUpdateTemplate(networkAddr),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           networkAddr + "/default",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"autocreatereversezone", "restartifneeded"},
 			},
 		},
 	})
 
 }
 
+func TestAccResourceNetworkAllocateFrom(t *testing.T) {
+	subnet := acctest.RandIntRange(201, 249)
+	containerAddr := fmt.Sprintf("10.%d.0.0/16", subnet)
+	resourceName := "infoblox_network.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			if err := testAccResourceNetworkDestroy(state); err != nil {
+				return err
+			}
+			return testAccResourceNetworkContainerDestroy(state)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceNetworkAllocateFromTemplate(containerAddr, fmt.Sprintf("10.%d.0.0/26", subnet)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceNetworkExists(fmt.Sprintf("10.%d.0.64/26", subnet), resourceName),
+					resource.TestCheckResourceAttr(resourceName, "network", fmt.Sprintf("10.%d.0.64/26", subnet)),
+					resource.TestCheckResourceAttr(resourceName, "allocate_from.0.network_container", containerAddr),
+				),
+			},
+		},
+	})
+}
+
 func testAccResourceNetworkDestroy(state *terraform.State) error {
-	infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "infoblox_network" {
 			continue
@@ -77,7 +110,7 @@ func testAccResourceNetworkExists(networkAddr, resourceName string) resource.Tes
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("\nInfoblox Network resource %s ID not set", resourceName)
 		}
-		infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
 		getAllARec := network.NewGetAllNetworks(fields)
 		err := infobloxClient.Do(getAllARec)
 		if err != nil {
@@ -120,3 +153,19 @@ func testAccResourceNetworkUpdateTemplate(networkAddr string) string {
     use_enablediscovery = true
 	}`, networkAddr)
 }
+
+func testAccResourceNetworkAllocateFromTemplate(containerAddr, excludedNetwork string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_network_container" "acctest" {
+	network = "%s"
+	}
+
+	resource "infoblox_network" "acctest" {
+	comment = "a network carved from a container"
+	allocate_from {
+		network_container = "${infoblox_network_container.acctest.network}"
+		prefix_length = 26
+		exclude = ["%s"]
+	}
+	}`, containerAddr, excludedNetwork)
+}
@@ -0,0 +1,184 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/skyinfoblox/api"
+	"github.com/sky-uk/skyinfoblox/api/networkview"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+	"net/http"
+)
+
+func resourceNetworkView() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetworkViewCreate,
+		Read:   resourceNetworkViewRead,
+		Update: resourceNetworkViewUpdate,
+		Delete: resourceNetworkViewDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("networkview", "name"),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the network view",
+				ValidateFunc: util.CheckLeadingTrailingSpaces,
+			},
+			"comment": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Comment for the network view; maximum 256 characters",
+				ValidateFunc: util.CheckLeadingTrailingSpaces,
+			},
+			"is_default": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the network view is the default one (read-only)",
+			},
+			"associated_dns_views": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The DNS views associated with the network view, through their network_view (read-only)",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"extattrs": util.ExtAttrsSchema(),
+		},
+	}
+}
+
+// resourceNetworkViewCreate - Creates a new network view
+func resourceNetworkViewCreate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	var networkViewCreate networkview.NetworkView
+
+	networkViewCreate.Name = d.Get("name").(string)
+	if v, ok := d.GetOk("comment"); ok {
+		comment := v.(string)
+		networkViewCreate.Comment = &comment
+	}
+	networkViewCreate.ExtAttrs = buildExtAttrs(d, m)
+
+	createAPI := networkview.NewCreate(networkViewCreate)
+	err := infobloxClient.Do(createAPI)
+	if err != nil {
+		return fmt.Errorf("Error creating the network view %s: %s", networkViewCreate.Name, err)
+	}
+	if skyinfoblox.IsConflict(createAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the network view already exists, import it rather than creating it - %s", createAPI.Error())
+	}
+	if createAPI.StatusCode() != http.StatusCreated {
+		return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %d returned - response %s", createAPI.StatusCode(), string(createAPI.RawResponse()))
+	}
+	d.SetId(*createAPI.ResponseObject().(*string))
+	return resourceNetworkViewRead(d, m)
+}
+
+// resourceNetworkViewRead - Reads the resource
+func resourceNetworkViewRead(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	getAPI := networkview.NewGet(d.Id(), []string{"name", "comment", "is_default", "associated_dns_views", "extattrs"})
+	err := infobloxClient.Do(getAPI)
+	if err != nil {
+		return fmt.Errorf("Could not read the network view %s", err)
+	}
+	if skyinfoblox.IsNotFound(getAPI.Error()) {
+		d.SetId("")
+		return nil
+	}
+	if getAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %d returned - response %s", getAPI.StatusCode(), string(getAPI.RawResponse()))
+	}
+
+	networkView := getAPI.ResponseObject().(*networkview.NetworkView)
+	d.SetId(networkView.Ref)
+	d.Set("name", networkView.Name)
+	d.Set("comment", networkView.Comment)
+	d.Set("is_default", networkView.IsDefault)
+	d.Set("associated_dns_views", networkView.AssociatedDNSViews)
+	d.Set("extattrs", flattenExtAttrs(d, m, networkView.ExtAttrs))
+	return nil
+}
+
+// resourceNetworkViewUpdate - Updates the resource
+func resourceNetworkViewUpdate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	hasChanges := false
+	var updateNetworkView networkview.NetworkView
+	updateNetworkView.Ref = d.Id()
+
+	if d.HasChange("name") {
+		updateNetworkView.Name = d.Get("name").(string)
+		hasChanges = true
+	}
+	if d.HasChange("comment") {
+		comment := d.Get("comment").(string)
+		updateNetworkView.Comment = &comment
+		hasChanges = true
+	}
+	if d.HasChange("extattrs") {
+		updateNetworkView.ExtAttrs = buildExtAttrs(d, m)
+		hasChanges = true
+	}
+
+	if hasChanges {
+		updateAPI := networkview.NewUpdate(updateNetworkView)
+		err := infobloxClient.Do(updateAPI)
+		if err != nil {
+			return fmt.Errorf("Error updating the network view %s", err)
+		}
+		if updateAPI.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Infoblox Update Error: Invalid HTTP response code %d returned - response %s", updateAPI.StatusCode(), string(updateAPI.RawResponse()))
+		}
+		d.SetId(*updateAPI.ResponseObject().(*string))
+	}
+	return resourceNetworkViewRead(d, m)
+}
+
+// networkViewNetwork - returns the object type and address of one of the IPv4 and IPv6 networks and network
+// containers of a network view, or an empty object type when it holds none. Only one object of each type is
+// fetched, as the view may hold many.
+func networkViewNetwork(infobloxClient *skyinfoblox.InfobloxClient, name string) (string, string, error) {
+	for _, objectType := range []string{"network", "networkcontainer", "ipv6network", "ipv6networkcontainer"} {
+		networks := new([]map[string]interface{})
+		searchAPI := api.NewSearch(objectType, map[string]string{"network_view": name, "_max_results": "1"}, []string{"network"}, networks)
+		err := infobloxClient.Do(searchAPI)
+		if err != nil {
+			return "", "", err
+		}
+		if searchAPI.StatusCode() != http.StatusOK {
+			return "", "", fmt.Errorf("Invalid HTTP response code %d returned - response %s", searchAPI.StatusCode(), string(searchAPI.RawResponse()))
+		}
+		if len(*networks) > 0 {
+			return objectType, fmt.Sprint((*networks)[0]["network"]), nil
+		}
+	}
+	return "", "", nil
+}
+
+// resourceNetworkViewDelete - Deletes the resource. NIOS deletes the networks of a network view along with it, so
+// the delete is refused while the network view still holds networks.
+func resourceNetworkViewDelete(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	name := d.Get("name").(string)
+	objectType, network, err := networkViewNetwork(infobloxClient, name)
+	if err != nil {
+		return fmt.Errorf("Could not list the networks of the network view %s: %s", name, err)
+	}
+	if objectType != "" {
+		return fmt.Errorf("Infoblox Delete Error: the network view %s still holds networks or network containers, e.g. the %s %s, delete them first", name, objectType, network)
+	}
+
+	deleteAPI := networkview.NewDelete(d.Id())
+	err = infobloxClient.Do(deleteAPI)
+	if err != nil {
+		return fmt.Errorf("Could not delete the network view %s", err)
+	}
+	if deleteAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Delete Error: Invalid HTTP response code %d returned - response %s", deleteAPI.StatusCode(), string(deleteAPI.RawResponse()))
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,231 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/sky-uk/skyinfoblox/api"
+	"github.com/sky-uk/skyinfoblox/api/networkview"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func TestAccResourceNetworkView(t *testing.T) {
+	name := fmt.Sprintf("acctest-network-view-%d", acctest.RandInt())
+	resourceName := "infoblox_network_view.acctest"
+	var ipv6Refs []string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			if err := testAccResourceDNSViewDestroy(state); err != nil {
+				return err
+			}
+			return testAccResourceNetworkViewDestroy(state)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceNetworkViewCreateTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceNetworkViewExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "comment", "tenant network view"),
+					resource.TestCheckResourceAttr(resourceName, "is_default", "false"),
+					resource.TestCheckResourceAttr(resourceName, "extattrs.%", "1"),
+				),
+			},
+			{
+				Config:      testAccResourceNetworkViewDuplicateTemplate(name),
+				ExpectError: regexp.MustCompile(`the network view already exists, import it rather than creating it`),
+			},
+			{
+				Config: testAccResourceNetworkViewUpdateTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceNetworkViewExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "comment", "tenant network view - updated"),
+					resource.TestCheckResourceAttr("infoblox_dns_view.acctest", "network_view", name),
+					resource.TestCheckResourceAttr("infoblox_network.acctest", "networkview", name),
+				),
+			},
+			{
+				Config:      testAccResourceNetworkViewOrphanNetworkTemplate(name),
+				ExpectError: regexp.MustCompile(`still holds networks or network containers, e.g. the network 10.250.0.0/24`),
+			},
+			{
+				PreConfig: func() {
+					refs, err := testAccResourceNetworkViewCreateIPv6Networks(name)
+					if err != nil {
+						t.Fatal(err)
+					}
+					ipv6Refs = refs
+				},
+				Config:      testAccResourceNetworkViewOrphanDNSViewTemplate(name),
+				ExpectError: regexp.MustCompile(`still holds networks or network containers, e.g. the ipv6network 2001:db8:1::/64`),
+			},
+			{
+				PreConfig: func() {
+					if err := testAccResourceNetworkViewDeleteIPv6Networks(ipv6Refs); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccResourceNetworkViewUpdateTemplate(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourceNetworkViewExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "associated_dns_views.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "associated_dns_views.0", name),
+				),
+			},
+			{
+				Config:            testAccResourceNetworkViewUpdateTemplate(name),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     name,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccResourceNetworkViewCreateIPv6Networks - creates an IPv6 network and network container in the network view,
+// straight through WAPI as the provider has no resources for them
+func testAccResourceNetworkViewCreateIPv6Networks(name string) ([]string, error) {
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
+	refs := make([]string, 0)
+	for objectType, network := range map[string]string{"ipv6network": "2001:db8:1::/64", "ipv6networkcontainer": "2001:db8:2::/48"} {
+		createAPI := api.NewBaseAPI(http.MethodPost, "/"+objectType, map[string]string{"network": network, "network_view": name}, new(string))
+		err := infobloxClient.Do(createAPI)
+		if err != nil {
+			return refs, err
+		}
+		if createAPI.StatusCode() != http.StatusCreated {
+			return refs, fmt.Errorf("Could not create the %s %s: %s", objectType, network, string(createAPI.RawResponse()))
+		}
+		refs = append(refs, *createAPI.ResponseObject().(*string))
+	}
+	return refs, nil
+}
+
+// testAccResourceNetworkViewDeleteIPv6Networks - deletes the objects created by testAccResourceNetworkViewCreateIPv6Networks
+func testAccResourceNetworkViewDeleteIPv6Networks(refs []string) error {
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
+	for _, ref := range refs {
+		deleteAPI := api.NewBaseAPI(http.MethodDelete, "/"+ref, nil, new(string))
+		err := infobloxClient.Do(deleteAPI)
+		if err != nil {
+			return err
+		}
+		if deleteAPI.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Could not delete %s: %s", ref, string(deleteAPI.RawResponse()))
+		}
+	}
+	return nil
+}
+
+func testAccResourceNetworkViewDestroy(state *terraform.State) error {
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "infoblox_network_view" {
+			continue
+		}
+		api := networkview.NewGet(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusNotFound {
+			return fmt.Errorf("Network view %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccResourceNetworkViewExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("\nInfoblox network view resource %s not found in resources", resourceName)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("\nInfoblox network view resource %s ID not set", resourceName)
+		}
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
+		api := networkview.NewGet(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Could not find %s", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccResourceNetworkViewCreateTemplate(name string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_network_view" "acctest" {
+	name = "%s"
+	comment = "tenant network view"
+	extattrs {
+	  Tenant = "acctest"
+	}
+	}`, name)
+}
+
+// testAccResourceNetworkViewDuplicateTemplate - declares a second network view with the name of the first one
+func testAccResourceNetworkViewDuplicateTemplate(name string) string {
+	return testAccResourceNetworkViewCreateTemplate(name) + fmt.Sprintf(`
+
+	resource "infoblox_network_view" "duplicate" {
+	name = "%s"
+	depends_on = ["infoblox_network_view.acctest"]
+	}`, name)
+}
+
+func testAccResourceNetworkViewUpdateTemplate(name string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_network_view" "acctest" {
+	name = "%s"
+	comment = "tenant network view - updated"
+	extattrs {
+	  Tenant = "acctest"
+	}
+	}
+
+	resource "infoblox_dns_view" "acctest" {
+	name = "%s"
+	network_view = "${infoblox_network_view.acctest.name}"
+	}
+
+	resource "infoblox_network" "acctest" {
+	network = "10.250.0.0/24"
+	networkview = "${infoblox_network_view.acctest.name}"
+	}`, name, name)
+}
+
+// testAccResourceNetworkViewOrphanNetworkTemplate - drops the network view while keeping its DNS view and network
+func testAccResourceNetworkViewOrphanNetworkTemplate(name string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_dns_view" "acctest" {
+	name = "%s"
+	network_view = "%s"
+	}
+
+	resource "infoblox_network" "acctest" {
+	network = "10.250.0.0/24"
+	networkview = "%s"
+	}`, name, name, name)
+}
+
+// testAccResourceNetworkViewOrphanDNSViewTemplate - drops the network view and its IPv4 network while keeping its
+// DNS view, leaving the IPv6 networks created outside Terraform
+func testAccResourceNetworkViewOrphanDNSViewTemplate(name string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_dns_view" "acctest" {
+	name = "%s"
+	network_view = "%s"
+	}`, name, name)
+}
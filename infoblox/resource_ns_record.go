@@ -15,6 +15,9 @@ func resourceNSRecord() *schema.Resource {
 		Read:   resourceNSRecordRead,
 		Update: resourceNSRecordUpdate,
 		Delete: resourceNSRecordDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("record:ns", "name", "view"),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"zone_name": {
@@ -105,7 +108,7 @@ func buildNSRecordNameServerAddresses(addresses []nameserver.ZoneNameServer) []m
 func resourceNSRecordCreate(d *schema.ResourceData, m interface{}) error {
 
 	var nsRecord nameserver.NSRecord
-	client := m.(*skyinfoblox.InfobloxClient)
+	client := m.(*providerMeta).client
 
 	if v, ok := d.GetOk("zone_name"); ok && v != "" {
 		nsRecord.Name = v.(string)
@@ -138,15 +141,18 @@ func resourceNSRecordCreate(d *schema.ResourceData, m interface{}) error {
 func resourceNSRecordRead(d *schema.ResourceData, m interface{}) error {
 
 	returnFields := []string{"name", "addresses", "nameserver", "view", "ms_delegation_name"}
-	client := m.(*skyinfoblox.InfobloxClient)
+	client := m.(*providerMeta).client
 	reference := d.Id()
 
 	getNSRecordAPI := nameserver.NewGet(reference, returnFields)
 	err := client.Do(getNSRecordAPI)
 	httpStatus := getNSRecordAPI.StatusCode()
-
+	if skyinfoblox.IsNotFound(getNSRecordAPI.Error()) {
+		d.SetId("")
+		return nil
+	}
 	if err != nil || httpStatus < http.StatusOK || httpStatus >= http.StatusBadRequest {
-		return fmt.Errorf("Infoblox NS Record read for %s failed with status code %d and error: %+v", reference, httpStatus, err)
+		return fmt.Errorf("Infoblox NS Record read for %s failed with status code %d and error: %+v", reference, httpStatus, getNSRecordAPI.Error())
 	}
 
 	response := *getNSRecordAPI.ResponseObject().(*nameserver.NSRecord)
@@ -199,7 +205,7 @@ func resourceNSRecordUpdate(d *schema.ResourceData, m interface{}) error {
 
 	if hasChanges {
 		returnFields := []string{"name", "addresses", "nameserver", "view", "ms_delegation_name"}
-		client := m.(*skyinfoblox.InfobloxClient)
+		client := m.(*providerMeta).client
 		nsRecordObject.Reference = d.Id()
 
 		updateNSRecordAPI := nameserver.NewUpdate(nsRecordObject, returnFields)
@@ -225,7 +231,7 @@ func resourceNSRecordUpdate(d *schema.ResourceData, m interface{}) error {
 
 func resourceNSRecordDelete(d *schema.ResourceData, m interface{}) error {
 
-	client := m.(*skyinfoblox.InfobloxClient)
+	client := m.(*providerMeta).client
 	reference := d.Id()
 
 	deleteNSRecordAPI := nameserver.NewDelete(reference)
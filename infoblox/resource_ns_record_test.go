@@ -5,7 +5,6 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/records/nameserver"
 	"regexp"
 	"testing"
@@ -95,7 +94,7 @@ func testAccInfobloxNSRecordCheckValueInKeyPattern(nsRecordResource string, keyP
 
 func testAccInfobloxNSRecordCheckDestroy(state *terraform.State, createNameServer, updateNameServer string) error {
 
-	client := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	client := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "infoblox_ns_record" {
@@ -131,7 +130,7 @@ func testAccInfobloxNSRecordCheckExists(nameServer, nameServerResource string) r
 			return fmt.Errorf("\nInfoblox NS record ID not set for %s in resources", nameServer)
 		}
 
-		client := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		client := testAccProvider.Meta().(*providerMeta).client
 		api := nameserver.NewGetAll()
 		err := client.Do(api)
 		if err != nil {
@@ -15,6 +15,9 @@ func resourceNSGroupDelegation() *schema.Resource {
 		Read:   resourceNSGroupDelegationRead,
 		Update: resourceNSGroupDelegationUpdate,
 		Delete: resourceNSGroupDelegationDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("nsgroup:delegation", "name"),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -37,7 +40,7 @@ func resourceNSGroupDelegation() *schema.Resource {
 func resourceNSGroupDelegationCreate(d *schema.ResourceData, m interface{}) error {
 
 	var nsGroupDelegationObject nsgroupdelegation.NSGroupDelegation
-	client := m.(*skyinfoblox.InfobloxClient)
+	client := m.(*providerMeta).client
 
 	if v, ok := d.GetOk("name"); ok && v != "" {
 		nsGroupDelegationObject.Name = v.(string)
@@ -64,12 +67,12 @@ func resourceNSGroupDelegationCreate(d *schema.ResourceData, m interface{}) erro
 func resourceNSGroupDelegationRead(d *schema.ResourceData, m interface{}) error {
 
 	reference := d.Id()
-	client := m.(*skyinfoblox.InfobloxClient)
+	client := m.(*providerMeta).client
 
 	getNSGroupDelegationAPI := nsgroupdelegation.NewGet(reference, nsgroupdelegation.RequestReturnFields)
 	err := client.Do(getNSGroupDelegationAPI)
 	httpStatus := getNSGroupDelegationAPI.StatusCode()
-	if httpStatus == http.StatusNotFound {
+	if skyinfoblox.IsNotFound(getNSGroupDelegationAPI.Error()) {
 		d.SetId("")
 		return nil
 	}
@@ -111,7 +114,7 @@ func resourceNSGroupDelegationUpdate(d *schema.ResourceData, m interface{}) erro
 
 	if hasChanges {
 		nsGroupDelegationObject.Reference = d.Id()
-		client := m.(*skyinfoblox.InfobloxClient)
+		client := m.(*providerMeta).client
 
 		nsGroupDelegationUpdateAPI := nsgroupdelegation.NewUpdate(nsGroupDelegationObject, nsgroupdelegation.RequestReturnFields)
 		err := client.Do(nsGroupDelegationUpdateAPI)
@@ -132,7 +135,7 @@ func resourceNSGroupDelegationUpdate(d *schema.ResourceData, m interface{}) erro
 
 func resourceNSGroupDelegationDelete(d *schema.ResourceData, m interface{}) error {
 
-	client := m.(*skyinfoblox.InfobloxClient)
+	client := m.(*providerMeta).client
 	reference := d.Id()
 
 	nsGroupDelegationDeleteAPI := nsgroupdelegation.NewDelete(reference)
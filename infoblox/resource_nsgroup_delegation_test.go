@@ -5,7 +5,6 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/nsgroupdelegation"
 	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
 	"regexp"
@@ -69,7 +68,7 @@ func TestAccInfobloxNSGroupDelegationBasic(t *testing.T) {
 
 func testAccInfobloxNSGroupDelegationCheckDestroy(state *terraform.State, name string) error {
 
-	client := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	client := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "infoblox_ns_group_delegation" {
@@ -103,7 +102,7 @@ func testAccInfobloxNSGroupDelegationCheckExists(name, resourceName string) reso
 			return fmt.Errorf("\nInfoblox NS Group Delegation ID not set for %s in resources", name)
 		}
 
-		client := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		client := testAccProvider.Meta().(*providerMeta).client
 		api := nsgroupdelegation.NewGetAll()
 		err := client.Do(api)
 		if err != nil {
@@ -15,6 +15,9 @@ func resourcePermission() *schema.Resource {
 		Read:   resourcePermissionRead,
 		Update: resourcePermissionUpdate,
 		Delete: resourcePermissionDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("permission"),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"group": {
@@ -60,7 +63,7 @@ func validatePermissionType(v interface{}, k string) (ws []string, errors []erro
 func resourcePermissionCreate(d *schema.ResourceData, m interface{}) error {
 
 	var permissionObject permission.Permission
-	client := m.(*skyinfoblox.InfobloxClient)
+	client := m.(*providerMeta).client
 	groupAndRoleCount, objectAndResourceTypeCount := 0, 0
 
 	if v, ok := d.GetOk("group"); ok && v != "" {
@@ -109,12 +112,12 @@ func resourcePermissionCreate(d *schema.ResourceData, m interface{}) error {
 
 func resourcePermissionRead(d *schema.ResourceData, m interface{}) error {
 	reference := d.Id()
-	client := m.(*skyinfoblox.InfobloxClient)
+	client := m.(*providerMeta).client
 
 	getPermissionAPI := permission.NewGet(reference)
 	err := client.Do(getPermissionAPI)
 	httpStatus := getPermissionAPI.StatusCode()
-	if httpStatus == http.StatusNotFound {
+	if skyinfoblox.IsNotFound(getPermissionAPI.Error()) {
 		d.SetId("")
 		return nil
 	}
@@ -184,7 +187,7 @@ func resourcePermissionUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if hasChanges {
-		client := m.(*skyinfoblox.InfobloxClient)
+		client := m.(*providerMeta).client
 		updatePermissionAPI := permission.NewUpdate(reference, permissionObject)
 		err := client.Do(updatePermissionAPI)
 		httpStatus := updatePermissionAPI.StatusCode()
@@ -209,7 +212,7 @@ func resourcePermissionUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourcePermissionDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*skyinfoblox.InfobloxClient)
+	client := m.(*providerMeta).client
 	reference := d.Id()
 
 	deletePermissionAPI := permission.NewDelete(reference)
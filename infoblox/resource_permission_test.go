@@ -5,7 +5,6 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/permission"
 	"testing"
 )
@@ -59,7 +58,7 @@ func TestAccInfobloxPermissionBasic(t *testing.T) {
 
 func testAccInfobloxPermissionCheckExists(testPermision permission.Permission) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
-		client := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		client := testAccProvider.Meta().(*providerMeta).client
 		api := permission.NewGetAll()
 		err := client.Do(api)
 		if err != nil {
@@ -79,7 +78,7 @@ func testAccInfobloxPermissionCheckExists(testPermision permission.Permission) r
 }
 
 func testAccInfobloxPermissionCheckDestroy(state *terraform.State, testPermision permission.Permission) error {
-	client := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	client := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "infoblox_permission" {
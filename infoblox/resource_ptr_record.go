@@ -0,0 +1,220 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/skyinfoblox/api/records"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+	"net"
+	"net/http"
+)
+
+func resourcePTRRecord() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePTRRecordCreate,
+		Read:   resourcePTRRecordRead,
+		Update: resourcePTRRecordUpdate,
+		Delete: resourcePTRRecordDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("record:ptr", "name", "view"),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"ptrdname": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The FQDN the PTR record points to",
+				ValidateFunc: util.CheckLeadingTrailingSpaces,
+			},
+			"address": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				Description:      "The IPv4 or IPv6 address the PTR record is for",
+				ConflictsWith:    []string{"name"},
+				ValidateFunc:     util.ValidateIPAddress,
+				DiffSuppressFunc: util.SuppressEquivalentIPAddresses,
+			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "The in-addr.arpa or ip6.arpa name of the PTR record",
+				ConflictsWith: []string{"address"},
+				ValidateFunc:  util.CheckLeadingTrailingSpaces,
+			},
+			"view": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The DNS view in which the record resides",
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "DNS Zone for the record",
+			},
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "TTL in seconds for the record",
+				ValidateFunc: util.ValidateUnsignedInteger,
+			},
+			"use_ttl": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ref": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Internal Reference for the record",
+			},
+			"extattrs": util.ExtAttrsSchema(),
+		},
+	}
+}
+
+func resourcePTRRecordCreate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	var createPTRRecord records.PTRRecord
+
+	createPTRRecord.PtrdName = d.Get("ptrdname").(string)
+	if v, ok := d.GetOk("address"); ok {
+		address := v.(string)
+		if net.ParseIP(address).To4() != nil {
+			createPTRRecord.IPv4 = address
+		} else {
+			createPTRRecord.IPv6 = address
+		}
+	} else if v, ok := d.GetOk("name"); ok {
+		createPTRRecord.Name = v.(string)
+	} else {
+		return fmt.Errorf("one of address or name is required")
+	}
+	if v, ok := d.GetOk("view"); ok {
+		createPTRRecord.View = v.(string)
+	}
+	if v, ok := d.GetOk("ttl"); ok {
+		createPTRRecord.TTL = uint(v.(int))
+	}
+	useTTL := d.Get("use_ttl").(bool)
+	createPTRRecord.UseTTL = &useTTL
+	if v, ok := d.GetOk("comment"); ok {
+		comment := v.(string)
+		createPTRRecord.Comment = &comment
+	}
+	createPTRRecord.ExtAttrs = buildExtAttrs(d, m)
+
+	createAPI := records.NewCreatePTRRecord(createPTRRecord)
+	err := infobloxClient.Do(createAPI)
+	if err != nil {
+		return fmt.Errorf("Infoblox Create Error: %+v", err)
+	}
+	if skyinfoblox.IsConflict(createAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the PTR record already exists, import it rather than creating it - %s", createAPI.Error())
+	}
+	if createAPI.StatusCode() != http.StatusCreated {
+		return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %d returned - response %s", createAPI.StatusCode(), createAPI.GetResponse())
+	}
+	d.SetId(createAPI.GetResponse())
+	return resourcePTRRecordRead(d, m)
+}
+
+func resourcePTRRecordRead(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	fields := []string{"name", "ptrdname", "ipv4addr", "ipv6addr", "view", "zone", "ttl", "use_ttl", "comment", "extattrs"}
+	getAPI := records.NewGetPTRRecord(d.Id(), fields)
+	err := infobloxClient.Do(getAPI)
+	if err != nil {
+		return fmt.Errorf("Infoblox Read Error: %+v", err)
+	}
+	if skyinfoblox.IsNotFound(getAPI.Error()) {
+		d.SetId("")
+		return nil
+	}
+	if getAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %d returned - response %s", getAPI.StatusCode(), string(getAPI.RawResponse()))
+	}
+
+	record := getAPI.GetResponse()
+	d.SetId(record.Ref)
+	d.Set("ptrdname", record.PtrdName)
+	d.Set("name", record.Name)
+	if record.IPv4 != "" {
+		d.Set("address", record.IPv4)
+	} else {
+		d.Set("address", record.IPv6)
+	}
+	d.Set("view", record.View)
+	d.Set("zone", record.Zone)
+	d.Set("ttl", record.TTL)
+	d.Set("use_ttl", record.UseTTL)
+	d.Set("comment", record.Comment)
+	d.Set("ref", record.Ref)
+	d.Set("extattrs", flattenExtAttrs(d, m, record.ExtAttrs))
+	return nil
+}
+
+func resourcePTRRecordUpdate(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	var updatePTRRecord records.PTRRecord
+	hasChanges := false
+
+	if d.HasChange("ptrdname") {
+		updatePTRRecord.PtrdName = d.Get("ptrdname").(string)
+		hasChanges = true
+	}
+	if d.HasChange("ttl") {
+		updatePTRRecord.TTL = uint(d.Get("ttl").(int))
+		hasChanges = true
+	}
+	if d.HasChange("use_ttl") {
+		useTTL := d.Get("use_ttl").(bool)
+		updatePTRRecord.UseTTL = &useTTL
+		hasChanges = true
+	}
+	if d.HasChange("comment") {
+		comment := d.Get("comment").(string)
+		updatePTRRecord.Comment = &comment
+		hasChanges = true
+	}
+	if d.HasChange("extattrs") {
+		updatePTRRecord.ExtAttrs = buildExtAttrs(d, m)
+		hasChanges = true
+	}
+
+	if hasChanges {
+		updateAPI := records.NewUpdatePTRRecord(d.Id(), updatePTRRecord)
+		err := infobloxClient.Do(updateAPI)
+		if err != nil {
+			return fmt.Errorf("Infoblox Update Error: %+v", err)
+		}
+		if updateAPI.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Infoblox Update Error: Invalid HTTP response code %d returned - response %s", updateAPI.StatusCode(), updateAPI.GetResponse())
+		}
+		d.SetId(updateAPI.GetResponse())
+	}
+	return resourcePTRRecordRead(d, m)
+}
+
+func resourcePTRRecordDelete(d *schema.ResourceData, m interface{}) error {
+	infobloxClient := m.(*providerMeta).client
+	deleteAPI := records.NewDelete(d.Id())
+	err := infobloxClient.Do(deleteAPI)
+	if err != nil {
+		return fmt.Errorf("Infoblox Delete Error: %+v", err)
+	}
+	if deleteAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Delete Error: Invalid HTTP response code %d returned - response %s", deleteAPI.StatusCode(), deleteAPI.GetResponse())
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,115 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/sky-uk/skyinfoblox/api/records"
+	"net/http"
+	"testing"
+)
+
+func TestAccResourcePTRRecord(t *testing.T) {
+
+	randInt := acctest.RandInt()
+	ptrdName := fmt.Sprintf("ptr-record-test-%d.slupaas.bskyb.com", randInt)
+	address := fmt.Sprintf("10.4.%d.%d", acctest.RandIntRange(0, 255), acctest.RandIntRange(1, 254))
+	resourceName := "infoblox_ptr_record.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccResourcePTRRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePTRRecordCreateTemplate(ptrdName, address),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourcePTRRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "ptrdname", ptrdName),
+					resource.TestCheckResourceAttr(resourceName, "address", address),
+					resource.TestCheckResourceAttr(resourceName, "view", "default"),
+				),
+			},
+			{
+				Config: testAccResourcePTRRecordUpdateTemplate(ptrdName, address),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourcePTRRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "ptrdname", "updated-"+ptrdName),
+					resource.TestCheckResourceAttr(resourceName, "address", address),
+					resource.TestCheckResourceAttr(resourceName, "comment", "an updated PTR record"),
+				),
+			},
+			{
+				Config:            testAccResourcePTRRecordUpdateTemplate(ptrdName, address),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccResourcePTRRecordCreateTemplate(ptrdName, address),
+				Check: resource.ComposeTestCheckFunc(
+					testAccResourcePTRRecordExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "comment", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourcePTRRecordDestroy(state *terraform.State) error {
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "infoblox_ptr_record" {
+			continue
+		}
+		api := records.NewGetPTRRecord(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusNotFound {
+			return fmt.Errorf("PTR record %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccResourcePTRRecordExists(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("\nInfoblox PTR record resource %s not found in resources", resourceName)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("\nInfoblox PTR record resource %s ID not set", resourceName)
+		}
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
+		api := records.NewGetPTRRecord(rs.Primary.ID, nil)
+		err := infobloxClient.Do(api)
+		if err != nil {
+			return err
+		}
+		if api.StatusCode() != http.StatusOK {
+			return fmt.Errorf("Could not find %s", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccResourcePTRRecordCreateTemplate(ptrdName, address string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_ptr_record" "acctest" {
+	ptrdname = "%s"
+	address = "%s"
+	}`, ptrdName, address)
+}
+
+func testAccResourcePTRRecordUpdateTemplate(ptrdName, address string) string {
+	return fmt.Sprintf(`
+	resource "infoblox_ptr_record" "acctest" {
+	ptrdname = "updated-%s"
+	address = "%s"
+	comment = "an updated PTR record"
+	}`, ptrdName, address)
+}
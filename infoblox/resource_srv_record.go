@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/records"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
 	"log"
 )
 
@@ -14,6 +15,9 @@ func resourceSRVRecord() *schema.Resource {
 		Read:   resourceSRVRecordRead,
 		Update: resourceSRVRecordUpdate,
 		Delete: resourceSRVRecordDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("record:srv", "name", "view"),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -64,12 +68,13 @@ func resourceSRVRecord() *schema.Resource {
 				Optional:    true,
 				Description: "Comment for the record; maximum 256 characters",
 			},
+			"extattrs": util.ExtAttrsSchema(),
 		},
 	}
 }
 
 func resourceSRVRecordCreate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var srvRecord records.GenericRecord
 	recordType := "srv"
 
@@ -115,6 +120,7 @@ func resourceSRVRecordCreate(d *schema.ResourceData, m interface{}) error {
 	if v, ok := d.GetOk("comment"); ok {
 		srvRecord.Comment = v.(string)
 	}
+	srvRecord.ExtAttrs = buildExtAttrs(d, m)
 
 	createAPI := records.NewCreateRecord(recordType, srvRecord)
 
@@ -123,6 +129,9 @@ func resourceSRVRecordCreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Infoblox Create Error: %+v", err)
 	}
 
+	if skyinfoblox.IsConflict(createAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the SRV record already exists, import it rather than creating it - %s", createAPI.Error())
+	}
 	if createAPI.StatusCode() != 201 {
 		return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %+v returned. Response object was %+v", createAPI.StatusCode(), createAPI.GetResponse())
 	}
@@ -134,9 +143,9 @@ func resourceSRVRecordCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSRVRecordRead(d *schema.ResourceData, m interface{}) error {
-	returnFields := []string{"name", "comment", "port", "priority", "target", "weight", "zone", "use_ttl", "ttl"}
+	returnFields := []string{"name", "comment", "port", "priority", "target", "weight", "view", "zone", "use_ttl", "ttl", "extattrs"}
 
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	resourceReference := d.Id()
 	getSingleSRVAPI := records.NewGetSRVRecord(resourceReference, returnFields)
 
@@ -144,9 +153,12 @@ func resourceSRVRecordRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return fmt.Errorf("Infoblox Read Error: %+v", err)
 	}
-	if getSingleSRVAPI.StatusCode() != 200 {
+	if skyinfoblox.IsNotFound(getSingleSRVAPI.Error()) {
 		d.SetId("")
-		return fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %+v returned. Response object was %+v", getSingleSRVAPI.StatusCode(), getSingleSRVAPI.GetResponse())
+		return nil
+	}
+	if getSingleSRVAPI.StatusCode() != 200 {
+		return fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %+v returned. Response object was %+v", getSingleSRVAPI.StatusCode(), getSingleSRVAPI.Error())
 	}
 
 	response := getSingleSRVAPI.GetResponse()
@@ -157,15 +169,18 @@ func resourceSRVRecordRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("priority", response.Priority)
 	d.Set("target", response.Target)
 	d.Set("weight", response.Weight)
+	d.Set("view", response.View)
 	d.Set("zone", response.Zone)
 	d.Set("ttl", response.TTL)
+	d.Set("use_ttl", response.UseTTL)
 	d.Set("ref", response.Ref)
+	d.Set("extattrs", flattenExtAttrs(d, m, response.ExtAttrs))
 
 	return nil
 }
 
 func resourceSRVRecordUpdate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	hasChanges := false
 	var recordReference string
 	var updatedSVR records.GenericRecord
@@ -230,11 +245,16 @@ func resourceSRVRecordUpdate(d *schema.ResourceData, m interface{}) error {
 		updatedSVR.Comment = newComment.(string)
 	}
 
+	if d.HasChange("extattrs") {
+		hasChanges = true
+		updatedSVR.ExtAttrs = buildExtAttrs(d, m)
+	}
+
 	if hasChanges {
 		updateAPI := records.NewUpdateRecord(recordReference, updatedSVR)
 		changeErr := infobloxClient.Do(updateAPI)
 		if changeErr != nil {
-			log.Printf(fmt.Sprintf("[DEBUG] Error updating  SRV record: %s", changeErr))
+			log.Printf("[DEBUG] Error updating  SRV record: %s", changeErr)
 		}
 		d.SetId(updateAPI.GetResponse())
 		return resourceSRVRecordRead(d, m)
@@ -246,7 +266,7 @@ func resourceSRVRecordUpdate(d *schema.ResourceData, m interface{}) error {
 
 func resourceSRVRecordDelete(d *schema.ResourceData, m interface{}) error {
 	returnFields := []string{}
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	resourceReference := d.Id()
 	getSingleSRVAPI := records.NewGetSRVRecord(resourceReference, returnFields)
 
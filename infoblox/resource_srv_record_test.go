@@ -5,7 +5,6 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/records"
 	"testing"
 )
@@ -65,7 +64,7 @@ func testAccResourceSRVRecordExists(recordName, resourceName string) resource.Te
 			return fmt.Errorf("\nInfoblox SRV record resource %s ID not set", resourceName)
 		}
 
-		infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
 
 		returnFields := []string{"name"}
 
@@ -87,7 +86,7 @@ func testAccResourceSRVRecordExists(recordName, resourceName string) resource.Te
 }
 
 func testAccResourceSRVRecordDestroy(state *terraform.State, recordName string) error {
-	infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
 	returnFields := []string{"name"}
 
 	for _, rs := range state.RootModule().Resources {
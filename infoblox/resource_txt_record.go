@@ -1,29 +1,24 @@
 package infoblox
 
 import (
-	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/records"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
 	"log"
 	"net/http"
 )
 
-// RespError : what POST/PUT/DELETE requests returns in case of error.
-type RespError struct {
-	Error string `json:"Error"`
-	Code  string `json:"code"`
-	Text  string `json:"text"`
-}
-
 func resourceTXTRecord() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceTXTRecordCreate,
 		Read:   resourceTXTRecordRead,
 		Update: resourceTXTRecordUpdate,
 		Delete: resourceTXTRecordDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("record:txt", "name", "view"),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -61,12 +56,13 @@ func resourceTXTRecord() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"extattrs": util.ExtAttrsSchema(),
 		},
 	}
 }
 
 func resourceTXTRecordUpdate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	hasChanges := false
 	var record records.GenericRecord
 	fields := []string{"name", "text", "view", "ttl", "use_ttl", "comment"}
@@ -115,6 +111,11 @@ func resourceTXTRecordUpdate(d *schema.ResourceData, m interface{}) error {
 		record.Comment = value.(string)
 	}
 
+	if d.HasChange("extattrs") {
+		hasChanges = true
+		record.ExtAttrs = buildExtAttrs(d, m)
+	}
+
 	if hasChanges {
 		updateAPI := records.NewUpdateRecord(d.Id(), record)
 		updateErr := infobloxClient.Do(updateAPI)
@@ -135,13 +136,13 @@ func resourceTXTRecordUpdate(d *schema.ResourceData, m interface{}) error {
 
 		// if update fails first read back object from infoblox...
 		resourceTXTRecordRead(d, m)
-		return handleError(response)
+		return fmt.Errorf("Infoblox Update Error: Invalid HTTP response code %d returned - response %s", updateAPI.StatusCode(), string(updateAPI.RawResponse()))
 	}
 	return nil
 }
 
 func resourceTXTRecordDelete(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	deleteAPI := records.NewDelete(d.Id())
 
 	DeleteRecordErr := infobloxClient.Do(deleteAPI)
@@ -158,21 +159,11 @@ func resourceTXTRecordDelete(d *schema.ResourceData, m interface{}) error {
 		return nil
 	}
 
-	return handleError(responseObject)
-}
-
-func handleError(respObj string) error {
-	var respObjType RespError
-	err := json.Unmarshal([]byte(respObj), &respObjType)
-	if err != nil {
-		return err
-	}
-	errorStr := fmt.Sprintf("Infoblox REST API Error: %s", respObjType.Text)
-	return errors.New(errorStr)
+	return fmt.Errorf("Infoblox Delete Error: Invalid HTTP response code %d returned - response %s", deleteAPI.StatusCode(), string(deleteAPI.RawResponse()))
 }
 
 func resourceTXTRecordCreate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var record records.TXTRecord
 
 	if v, ok := d.GetOk("name"); ok {
@@ -205,6 +196,8 @@ func resourceTXTRecordCreate(d *schema.ResourceData, m interface{}) error {
 		record.Comment = v.(string)
 	}
 
+	record.ExtAttrs = buildExtAttrs(d, m)
+
 	createAPI := records.NewCreateTXTRecord(record)
 	createRecordErr := infobloxClient.Do(createAPI)
 	if createRecordErr != nil {
@@ -212,25 +205,31 @@ func resourceTXTRecordCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	response := createAPI.GetResponse()
+	if skyinfoblox.IsConflict(createAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the TXT record already exists, import it rather than creating it - %s", createAPI.Error())
+	}
 	if createAPI.StatusCode() == http.StatusCreated {
 		d.SetId(response)
 		resourceTXTRecordRead(d, m)
 		return nil
 	}
 
-	return handleError(response)
+	return fmt.Errorf("Infoblox Create Error: Invalid HTTP response code %d returned - response %s", createAPI.StatusCode(), string(createAPI.RawResponse()))
 }
 
 func resourceTXTRecordRead(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	ref := d.Id()
-	fields := []string{"name", "view", "zone", "ttl", "use_ttl", "text", "comment"}
+	fields := []string{"name", "view", "zone", "ttl", "use_ttl", "text", "comment", "extattrs"}
 	recordAPI := records.NewGetTXTRecord(ref, fields)
 	readErr := infobloxClient.Do(recordAPI)
 	if readErr != nil {
 		log.Println("[resourceTXTRecordRead]:Error reading the object...")
+		return fmt.Errorf("Infoblox Read Error: %+v", readErr)
+	}
+	if skyinfoblox.IsNotFound(recordAPI.Error()) {
 		d.SetId("")
-		return errors.New("Infoblox binding error")
+		return nil
 	}
 
 	if recordAPI.StatusCode() == http.StatusOK {
@@ -241,13 +240,13 @@ func resourceTXTRecordRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("view", record.View)
 		d.Set("zone", record.Zone)
 		d.Set("ttl", record.TTL)
+		d.Set("use_ttl", record.UseTTL)
 		d.Set("comment", record.Comment)
+		d.Set("extattrs", flattenExtAttrs(d, m, record.ExtAttrs))
 		return nil
 	}
 
-	d.SetId("")
-	errStr := fmt.Sprintf("[resourceTXTRecordRead]:Return code: %d", recordAPI.StatusCode())
-	return errors.New(errStr)
+	return fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %d returned - response %s", recordAPI.StatusCode(), string(recordAPI.RawResponse()))
 }
 
 func validateUINT(v interface{}, k string) (ws []string, errors []error) {
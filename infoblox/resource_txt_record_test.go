@@ -5,7 +5,6 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/records"
 	"net/http"
 	"testing"
@@ -58,7 +57,7 @@ func testAccResourceTXTRecordExists(recordName, resourceName string) resource.Te
 			return fmt.Errorf("\nInfoblox TXT record resource %s ID not set", resourceName)
 		}
 		ref := rs.Primary.ID
-		infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		infobloxClient := testAccProvider.Meta().(*providerMeta).client
 		fields := []string{"name", "view", "zone", "ttl", "use_ttl", "text", "comment"}
 		recAPI := records.NewGetTXTRecord(ref, fields)
 		err := infobloxClient.Do(recAPI)
@@ -73,7 +72,7 @@ func testAccResourceTXTRecordExists(recordName, resourceName string) resource.Te
 }
 
 func testAccResourceTXTRecordDestroy(state *terraform.State) error {
-	infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "infoblox_txtrecord" {
 			continue
@@ -15,8 +15,16 @@ func resourceZoneAuth() *schema.Resource {
 		Read:   resourceZoneAuthRead,
 		Update: resourceZoneAuthUpdate,
 		Delete: resourceZoneAuthDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("zone_auth", "fqdn", "view"),
+		},
 
 		Schema: map[string]*schema.Schema{
+			"ref": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Internal Reference for the zone",
+			},
 			"fqdn": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -166,7 +174,20 @@ func resourceZoneAuth() *schema.Resource {
 				Default:     false,
 				Optional:    true,
 			},
-			"allow_update":   util.AccessControlSchema(),
+			"allow_query": util.AccessControlSchema(),
+			"use_allow_query": {
+				Type:        schema.TypeBool,
+				Description: "Use flag for: allow_query",
+				Default:     false,
+				Optional:    true,
+			},
+			"allow_update": util.AccessControlSchema(),
+			"use_allow_update": {
+				Type:        schema.TypeBool,
+				Description: "Use flag for: allow_update",
+				Default:     false,
+				Optional:    true,
+			},
 			"allow_transfer": util.AccessControlSchema(),
 			"use_allow_transfer": {
 				Type:        schema.TypeBool,
@@ -174,13 +195,44 @@ func resourceZoneAuth() *schema.Resource {
 				Default:     false,
 				Optional:    true,
 			},
+			"allow_update_forwarding": {
+				Type:        schema.TypeBool,
+				Description: "Determines whether the secondaries forward the dynamic DNS updates allowed by update_forwarding to the primary",
+				Default:     false,
+				Optional:    true,
+			},
+			"update_forwarding": util.AccessControlSchema(),
+			"use_allow_update_forwarding": {
+				Type:        schema.TypeBool,
+				Description: "Use flag for: allow_update_forwarding",
+				Default:     false,
+				Optional:    true,
+			},
+			"dnssec": util.DNSSecSchema(),
+			"ds_records": {
+				Type:        schema.TypeList,
+				Description: "The DS records of the key-signing keys of the zone while it is signed, to publish in its parent zone",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"dnssec_ksk_rollover_date": {
+				Type:        schema.TypeInt,
+				Description: "The date, in seconds since the epoch, of the next key-signing key rollover (read-only)",
+				Computed:    true,
+			},
+			"dnssec_zsk_rollover_date": {
+				Type:        schema.TypeInt,
+				Description: "The date, in seconds since the epoch, of the next zone-signing key rollover (read-only)",
+				Computed:    true,
+			},
+			"extattrs": util.ExtAttrsSchema(),
 		},
 	}
 }
 
 func resourceZoneAuthCreate(d *schema.ResourceData, m interface{}) error {
 
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	// dnsZone is used for the initial request which creates the zone
 	var dnsZone zoneauth.DNSZone
 	// appendDNSZone is used for the second request after the zone has been created.
@@ -250,15 +302,46 @@ func resourceZoneAuthCreate(d *schema.ResourceData, m interface{}) error {
 		zoneLocked := v.(bool)
 		dnsZone.Locked = &zoneLocked
 	}
+	var err error
+	if v, ok := d.GetOk("allow_query"); ok && v != nil {
+		if dnsZone.AllowQuery, err = buildAcList(infobloxClient, v.([]interface{})); err != nil {
+			return err
+		}
+	}
+	if v, ok := d.GetOk("use_allow_query"); ok {
+		useAllowQuery := v.(bool)
+		dnsZone.UseAllowQuery = &useAllowQuery
+	}
 	if v, ok := d.GetOk("allow_update"); ok && v != nil {
-		dnsZone.AllowUpdate = util.BuildAcList(v.([]interface{}))
+		if dnsZone.AllowUpdate, err = buildAcList(infobloxClient, v.([]interface{})); err != nil {
+			return err
+		}
+	}
+	if v, ok := d.GetOk("use_allow_update"); ok {
+		useAllowUpdate := v.(bool)
+		dnsZone.UseAllowUpdate = &useAllowUpdate
 	}
 	if v, ok := d.GetOk("use_allow_transfer"); ok {
 		useAllowTransfer := v.(bool)
 		dnsZone.UseAllowTransfer = &useAllowTransfer
 	}
 	if v, ok := d.GetOk("allow_transfer"); ok && v != nil {
-		dnsZone.AllowTransfer = util.BuildAcList(v.([]interface{}))
+		if dnsZone.AllowTransfer, err = buildAcList(infobloxClient, v.([]interface{})); err != nil {
+			return err
+		}
+	}
+	if v, ok := d.GetOk("allow_update_forwarding"); ok {
+		allowUpdateForwarding := v.(bool)
+		dnsZone.AllowUpdateForwarding = &allowUpdateForwarding
+	}
+	if v, ok := d.GetOk("update_forwarding"); ok && v != nil {
+		if dnsZone.UpdateForwarding, err = buildAcList(infobloxClient, v.([]interface{})); err != nil {
+			return err
+		}
+	}
+	if v, ok := d.GetOk("use_allow_update_forwarding"); ok {
+		useAllowUpdateForwarding := v.(bool)
+		dnsZone.UseAllowUpdateForwarding = &useAllowUpdateForwarding
 	}
 
 	if v, ok := d.GetOk("restart_if_needed"); ok {
@@ -304,12 +387,21 @@ func resourceZoneAuthCreate(d *schema.ResourceData, m interface{}) error {
 		useCheckNamesPolicy := v.(bool)
 		dnsZone.UseCheckNamesPolicy = &useCheckNamesPolicy
 	}
+	if v, ok := d.GetOk("dnssec"); ok {
+		useDNSSecKeyParams := true
+		dnsZone.UseDDNSSecKeyParams = &useDNSSecKeyParams
+		dnsZone.DNSSecKeyParams = util.BuildDNSSecKeyParamsFromT(v.([]interface{}))
+	}
+	dnsZone.ExtAttrs = buildExtAttrs(d, m)
 
 	createAPI := zoneauth.NewCreate(dnsZone)
-	err := infobloxClient.Do(createAPI)
+	err = infobloxClient.Do(createAPI)
 	if err != nil {
 		return fmt.Errorf("Infoblox Zone Auth Create Error: %+v", err)
 	}
+	if skyinfoblox.IsConflict(createAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the zone already exists, import it rather than creating it - %s", createAPI.Error())
+	}
 	if createAPI.StatusCode() != http.StatusCreated {
 		return fmt.Errorf("Infoblox Zone Create Error: Invalid HTTP response code %d returned - response %s", createAPI.StatusCode(), createAPI.GetResponse())
 	}
@@ -317,42 +409,54 @@ func resourceZoneAuthCreate(d *schema.ResourceData, m interface{}) error {
 	ref := createAPI.GetResponse()
 	// We can't set some attributes on create. Therefore we need to make another call.
 	appendDNSZone.Reference = ref
-	appendAPI := zoneauth.NewUpdate(appendDNSZone, nil)
+	appendAPI := zoneauth.NewUpdate(appendDNSZone, []string{"fqdn"})
 	err = infobloxClient.Do(appendAPI)
 	if err != nil {
 		return fmt.Errorf("Infoblox Zone Auth Create Append Error: %+v ", err)
 	}
 	if appendAPI.StatusCode() != http.StatusOK {
-		return fmt.Errorf("Infoblox Zone Auth Create Append: Invalid HTTP response code %d returned - response %s", appendAPI.StatusCode(), appendAPI.GetResponse())
+		return fmt.Errorf("Infoblox Zone Auth Create Append: Invalid HTTP response code %d returned - response %s", appendAPI.StatusCode(), string(appendAPI.RawResponse()))
 	}
 
 	d.SetId(ref)
+	if _, ok := d.GetOk("dnssec"); ok {
+		if err := zoneAuthDNSSecOperation(infobloxClient, ref, zoneauth.DNSSecSign); err != nil {
+			return err
+		}
+	}
 	return resourceZoneAuthRead(d, m)
 }
 
 func returnFields() []string {
-	return []string{"fqdn", "comment", "zone_format", "view", "prefix", "soa_serial_number", "soa_default_ttl", "soa_negative_ttl", "soa_refresh", "soa_retry", "soa_expire", "copy_xfer_to_notify", "use_copy_xfer_to_notify", "disable", "dns_integrity_enable", "dns_integrity_member", "external_primaries", "external_secondaries", "grid_primary", "grid_secondaries", "grid_primary_shared_with_ms_parent_delegation", "locked", "locked_by", "network_view", "ns_group", "allow_update", "allow_transfer", "use_check_names_policy"}
+	return []string{"fqdn", "comment", "zone_format", "view", "prefix", "soa_serial_number", "soa_default_ttl", "soa_negative_ttl", "soa_refresh", "soa_retry", "soa_expire", "copy_xfer_to_notify", "use_copy_xfer_to_notify", "disable", "dns_integrity_enable", "dns_integrity_member", "external_primaries", "external_secondaries", "grid_primary", "grid_secondaries", "grid_primary_shared_with_ms_parent_delegation", "locked", "locked_by", "network_view", "ns_group", "allow_query", "use_allow_query", "allow_update", "use_allow_update", "allow_transfer", "use_allow_transfer", "allow_update_forwarding", "update_forwarding", "use_allow_update_forwarding", "use_check_names_policy", "dns_fqdn", "is_dnssec_signed", "dnssec_key_params", "use_dnssec_key_params", "dnssec_keys", "dnssec_ksk_rollover_date", "dnssec_zsk_rollover_date", "extattrs"}
 }
 
 func resourceZoneAuthRead(d *schema.ResourceData, m interface{}) error {
 
-	returnFields := returnFields()
+	returnFields, err := supportedFields(m, "zone_auth", returnFields())
+	if err != nil {
+		return err
+	}
 	resourceReference := d.Id()
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	getZone := zoneauth.NewGetSingleZone(resourceReference, returnFields)
 
-	err := infobloxClient.Do(getZone)
+	err = infobloxClient.Do(getZone)
 	if err != nil {
 		return fmt.Errorf("Error retrieving object using reference %s", resourceReference)
 	}
-	if getZone.StatusCode() == http.StatusNotFound {
+	if skyinfoblox.IsNotFound(getZone.Error()) {
 		d.SetId("")
 		return nil
 	}
+	if getZone.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %d returned - response %s", getZone.StatusCode(), string(getZone.RawResponse()))
+	}
 
 	response := getZone.GetResponse()
 
 	d.SetId(response.Reference)
+	d.Set("ref", response.Reference)
 	d.Set("fqdn", response.FQDN)
 	d.Set("view", response.View)
 	d.Set("comment", response.Comment)
@@ -381,20 +485,45 @@ func resourceZoneAuthRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("copy_xfer_to_notify", response.CopyXferToNotify)
 	d.Set("use_copy_xfer_to_notify", response.UseCopyXferNotify)
 	d.Set("use_check_names_policy", response.UseCheckNamesPolicy)
-	d.Set("allow_update", response.AllowUpdate)
-	d.Set("allow_transfer", response.AllowTransfer)
+	d.Set("allow_query", util.BuildAcListFromIBX(response.AllowQuery))
+	d.Set("use_allow_query", response.UseAllowQuery)
+	d.Set("allow_update", util.BuildAcListFromIBX(response.AllowUpdate))
+	d.Set("use_allow_update", response.UseAllowUpdate)
+	d.Set("allow_transfer", util.BuildAcListFromIBX(response.AllowTransfer))
+	d.Set("use_allow_transfer", response.UseAllowTransfer)
+	d.Set("allow_update_forwarding", response.AllowUpdateForwarding)
+	d.Set("update_forwarding", util.BuildAcListFromIBX(response.UpdateForwarding))
+	d.Set("use_allow_update_forwarding", response.UseAllowUpdateForwarding)
+	// the key parameters of the zone only matter while it is signed with them, rather than with the grid ones
+	if response.IsDNSSecSigned != nil && *response.IsDNSSecSigned && response.UseDDNSSecKeyParams != nil && *response.UseDDNSSecKeyParams {
+		d.Set("dnssec", util.BuildDNSSecFromIBX(response.DNSSecKeyParams))
+	} else {
+		d.Set("dnssec", []map[string]interface{}{})
+	}
+	dnsName := response.DNSFqdn
+	if dnsName == "" {
+		dnsName = response.FQDN
+	}
+	dsRecords, err := util.BuildDSRecords(dnsName, response.DNSSecKeys)
+	if err != nil {
+		return fmt.Errorf("Infoblox Read Error: %s", err)
+	}
+	d.Set("ds_records", dsRecords)
+	d.Set("dnssec_ksk_rollover_date", response.DNSSecKskRolloverDate)
+	d.Set("dnssec_zsk_rollover_date", response.DNSSecZskRolloverDate)
+	d.Set("extattrs", flattenExtAttrs(d, m, response.ExtAttrs))
 	return nil
 }
 
 func resourceZoneAuthUpdate(d *schema.ResourceData, m interface{}) error {
 
 	var updateZoneAuth zoneauth.DNSZone
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	hasChanges := false
-	returnFields := returnFields()
 	resourceReference := d.Id()
 	updateZoneAuth.Reference = resourceReference
 	gridTimer := true
+	sign := false
 
 	if d.HasChange("comment") {
 		if v, ok := d.GetOk("comment"); ok {
@@ -536,22 +665,106 @@ func resourceZoneAuthUpdate(d *schema.ResourceData, m interface{}) error {
 		updateZoneAuth.UseCheckNamesPolicy = &useCheckNamesPolicy
 		hasChanges = true
 	}
+	var err error
+	if d.HasChange("allow_query") {
+		if v, ok := d.GetOk("allow_query"); ok && v != nil {
+			if updateZoneAuth.AllowQuery, err = buildAcList(infobloxClient, v.([]interface{})); err != nil {
+				return err
+			}
+		} else {
+			updateZoneAuth.ClearedLists = append(updateZoneAuth.ClearedLists, "allow_query")
+		}
+		hasChanges = true
+	}
+	if d.HasChange("use_allow_query") {
+		useAllowQuery := d.Get("use_allow_query").(bool)
+		updateZoneAuth.UseAllowQuery = &useAllowQuery
+		hasChanges = true
+	}
 	if d.HasChange("allow_update") {
 		if v, ok := d.GetOk("allow_update"); ok && v != nil {
-			updateZoneAuth.AllowUpdate = util.BuildAcList(v.([]interface{}))
+			if updateZoneAuth.AllowUpdate, err = buildAcList(infobloxClient, v.([]interface{})); err != nil {
+				return err
+			}
+		} else {
+			updateZoneAuth.ClearedLists = append(updateZoneAuth.ClearedLists, "allow_update")
 		}
 		hasChanges = true
 	}
+	if d.HasChange("use_allow_update") {
+		useAllowUpdate := d.Get("use_allow_update").(bool)
+		updateZoneAuth.UseAllowUpdate = &useAllowUpdate
+		hasChanges = true
+	}
 	if d.HasChange("allow_transfer") {
 		if v, ok := d.GetOk("allow_transfer"); ok && v != nil {
-			updateZoneAuth.AllowTransfer = util.BuildAcList(v.([]interface{}))
+			if updateZoneAuth.AllowTransfer, err = buildAcList(infobloxClient, v.([]interface{})); err != nil {
+				return err
+			}
+		} else {
+			updateZoneAuth.ClearedLists = append(updateZoneAuth.ClearedLists, "allow_transfer")
+		}
+		hasChanges = true
+	}
+	if d.HasChange("use_allow_transfer") {
+		useAllowTransfer := d.Get("use_allow_transfer").(bool)
+		updateZoneAuth.UseAllowTransfer = &useAllowTransfer
+		hasChanges = true
+	}
+	if d.HasChange("allow_update_forwarding") {
+		allowUpdateForwarding := d.Get("allow_update_forwarding").(bool)
+		updateZoneAuth.AllowUpdateForwarding = &allowUpdateForwarding
+		hasChanges = true
+	}
+	if d.HasChange("update_forwarding") {
+		if v, ok := d.GetOk("update_forwarding"); ok && v != nil {
+			if updateZoneAuth.UpdateForwarding, err = buildAcList(infobloxClient, v.([]interface{})); err != nil {
+				return err
+			}
+		} else {
+			updateZoneAuth.ClearedLists = append(updateZoneAuth.ClearedLists, "update_forwarding")
 		}
 		hasChanges = true
 	}
+	if d.HasChange("use_allow_update_forwarding") {
+		useAllowUpdateForwarding := d.Get("use_allow_update_forwarding").(bool)
+		updateZoneAuth.UseAllowUpdateForwarding = &useAllowUpdateForwarding
+		hasChanges = true
+	}
+	if d.HasChange("extattrs") {
+		updateZoneAuth.ExtAttrs = buildExtAttrs(d, m)
+		hasChanges = true
+	}
+	if d.HasChange("dnssec") {
+		oldDNSSec, newDNSSec := d.GetChange("dnssec")
+		useDNSSecKeyParams := len(newDNSSec.([]interface{})) > 0
+		if useDNSSecKeyParams {
+			updateZoneAuth.DNSSecKeyParams = util.BuildDNSSecKeyParamsFromT(newDNSSec.([]interface{}))
+			if len(oldDNSSec.([]interface{})) == 0 {
+				// a zone signed with the grid key parameters is already signed
+				signed, err := zoneAuthIsSigned(infobloxClient, resourceReference)
+				if err != nil {
+					return err
+				}
+				sign = !signed
+			}
+		} else {
+			// The zone must be unsigned before the grid key parameters apply to it again
+			if err := zoneAuthDNSSecOperation(infobloxClient, resourceReference, zoneauth.DNSSecUnsign); err != nil {
+				return err
+			}
+		}
+		updateZoneAuth.UseDDNSSecKeyParams = &useDNSSecKeyParams
+		hasChanges = true
+	}
 
 	if hasChanges == true {
+		returnFields, err := supportedFields(m, "zone_auth", returnFields())
+		if err != nil {
+			return err
+		}
 		updateAPI := zoneauth.NewUpdate(updateZoneAuth, returnFields)
-		err := infobloxClient.Do(updateAPI)
+		err = infobloxClient.Do(updateAPI)
 		if err != nil {
 			return fmt.Errorf("Infoblox Zone Auth Update Error: %+v", err)
 		}
@@ -559,6 +772,11 @@ func resourceZoneAuthUpdate(d *schema.ResourceData, m interface{}) error {
 			return fmt.Errorf("Infoblox Zone Auth Update return code != 200")
 		}
 	}
+	if sign {
+		if err := zoneAuthDNSSecOperation(infobloxClient, resourceReference, zoneauth.DNSSecSign); err != nil {
+			return err
+		}
+	}
 	return resourceZoneAuthRead(d, m)
 }
 
@@ -566,7 +784,7 @@ func resourceZoneAuthDelete(d *schema.ResourceData, m interface{}) error {
 
 	returnFields := []string{"fqdn"}
 
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	resourceReference := d.Id()
 	getZoneAuthAPI := zoneauth.NewGetSingleZone(resourceReference, returnFields)
 
@@ -592,3 +810,30 @@ func resourceZoneAuthDelete(d *schema.ResourceData, m interface{}) error {
 	d.SetId("")
 	return nil
 }
+
+// zoneAuthIsSigned - tells whether a zone is signed with DNSSEC
+func zoneAuthIsSigned(infobloxClient *skyinfoblox.InfobloxClient, ref string) (bool, error) {
+	getZone := zoneauth.NewGetSingleZone(ref, []string{"is_dnssec_signed"})
+	err := infobloxClient.Do(getZone)
+	if err != nil {
+		return false, fmt.Errorf("Error retrieving object using reference %s: %+v", ref, err)
+	}
+	if getZone.StatusCode() != http.StatusOK {
+		return false, fmt.Errorf("Infoblox Read Error: Invalid HTTP response code %d returned - response %s", getZone.StatusCode(), string(getZone.RawResponse()))
+	}
+	signed := getZone.GetResponse().IsDNSSecSigned
+	return signed != nil && *signed, nil
+}
+
+// zoneAuthDNSSecOperation - calls the dnssec_operation function of a zone, e.g. to sign or unsign it
+func zoneAuthDNSSecOperation(infobloxClient *skyinfoblox.InfobloxClient, ref, operation string) error {
+	operationAPI := zoneauth.NewDNSSecOperation(ref, operation)
+	err := infobloxClient.Do(operationAPI)
+	if err != nil {
+		return fmt.Errorf("Infoblox Zone Auth DNSSEC %s Error: %+v", operation, err)
+	}
+	if operationAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Infoblox Zone Auth DNSSEC %s Error: Invalid HTTP response code %d returned - response %s", operation, operationAPI.StatusCode(), string(operationAPI.RawResponse()))
+	}
+	return nil
+}
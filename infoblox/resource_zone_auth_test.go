@@ -5,7 +5,6 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/zoneauth"
 	"regexp"
 	"strconv"
@@ -53,7 +52,7 @@ func TestAccInfobloxZoneAuthBasic(t *testing.T) {
 			},
 			{
 				Config:      testAccInfobloxZoneAuthInvalidAllowUpdateType(testFQDN),
-				ExpectError: regexp.MustCompile(`must be one of addressac or tsigac`),
+				ExpectError: regexp.MustCompile(`must be one of addressac, tsigac or namedacl`),
 			},
 			{
 				Config:      testAccInfobloxZoneAuthInvalidAllowUpdateTSIGAlgorithm(testFQDN),
@@ -164,6 +163,170 @@ func TestAccInfobloxZoneAuthBasic(t *testing.T) {
 	})
 }
 
+func TestAccInfobloxZoneAuthDNSSec(t *testing.T) {
+
+	testFQDN := "acctest-infoblox-zone-auth-dnssec-" + strconv.Itoa(acctest.RandInt()) + ".slupaas.bskyb.com"
+	testFQDNResourceName := "infoblox_zone_auth.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccInfobloxZoneAuthCheckDestroy(state, testFQDN)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccInfobloxZoneAuthDNSSecTemplate(testFQDN, "GOST", 1296000),
+				ExpectError: regexp.MustCompile(`must be one of`),
+			},
+			{
+				Config: testAccInfobloxZoneAuthDNSSecTemplate(testFQDN, "RSASHA256", 1296000),
+				Check: resource.ComposeTestCheckFunc(
+					testAccInfobloxZoneAuthExists(testFQDN, testFQDNResourceName),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "dnssec.#", "1"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "dnssec.0.ksk_algorithm.0.algorithm", "RSASHA256"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "dnssec.0.ksk_algorithm.0.size", "2048"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "dnssec.0.zsk_rollover", "1296000"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "dnssec.0.next_secure_type", "NSEC"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "ds_records.#", "1"),
+					resource.TestMatchResourceAttr(testFQDNResourceName, "ds_records.0", regexp.MustCompile(`^`+regexp.QuoteMeta(testFQDN)+`\. IN DS [0-9]+ 8 2 [0-9A-F]{64}$`)),
+				),
+			},
+			{
+				Config: testAccInfobloxZoneAuthDNSSecTemplate(testFQDN, "RSASHA256", 2592000),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testFQDNResourceName, "dnssec.0.zsk_rollover", "2592000"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "ds_records.#", "1"),
+				),
+			},
+			{
+				// the zone stays signed, with the grid key parameters
+				PreConfig: func() {
+					if err := testAccInfobloxZoneAuthSetUseDNSSecKeyParams(testFQDN, false); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccInfobloxZoneAuthDNSSecTemplate(testFQDN, "RSASHA256", 2592000),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccInfobloxZoneAuthDNSSecTemplate(testFQDN, "RSASHA256", 2592000),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testFQDNResourceName, "dnssec.#", "1"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "dnssec.0.zsk_rollover", "2592000"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "ds_records.#", "1"),
+				),
+			},
+			{
+				Config: testAccInfobloxZoneAuthMinimalTemplate(testFQDN),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testFQDNResourceName, "dnssec.#", "0"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "ds_records.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInfobloxZoneAuthAccessControl(t *testing.T) {
+
+	testFQDN := "acctest-infoblox-zone-auth-acl-" + strconv.Itoa(acctest.RandInt()) + ".slupaas.bskyb.com"
+	testNamedACL := "acctest-zone-auth-acl-" + strconv.Itoa(acctest.RandInt())
+	testFQDNResourceName := "infoblox_zone_auth.acctest"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			if err := testAccResourceNamedACLDestroy(state); err != nil {
+				return err
+			}
+			return testAccInfobloxZoneAuthCheckDestroy(state, testFQDN)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInfobloxZoneAuthAccessControlTemplate(testFQDN, testNamedACL),
+				Check: resource.ComposeTestCheckFunc(
+					testAccInfobloxZoneAuthExists(testFQDN, testFQDNResourceName),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_query.#", "2"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_query.0.type", "namedacl"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_query.0.name", testNamedACL),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_query.1.type", "addressac"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_query.1.address", "192.168.200.0/24"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_query.1.permission", "DENY"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "use_allow_query", "true"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_transfer.#", "1"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_transfer.0.type", "tsigac"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_transfer.0.tsig_key_name", "transfer.key"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "use_allow_transfer", "true"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_update.#", "1"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "use_allow_update", "true"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_update_forwarding", "true"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "update_forwarding.#", "1"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "update_forwarding.0.name", testNamedACL),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "use_allow_update_forwarding", "true"),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := testAccInfobloxZoneAuthSetAllowTransfer(testFQDN, "10.0.0.1"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccInfobloxZoneAuthAccessControlTemplate(testFQDN, testNamedACL),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccInfobloxZoneAuthAccessControlTemplate(testFQDN, testNamedACL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_transfer.#", "1"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_transfer.0.type", "tsigac"),
+				),
+			},
+			{
+				Config: testAccInfobloxZoneAuthMinimalTemplate(testFQDN),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_query.#", "0"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_transfer.#", "0"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "allow_update.#", "0"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "update_forwarding.#", "0"),
+					resource.TestCheckResourceAttr(testFQDNResourceName, "use_allow_query", "false"),
+				),
+			},
+		},
+	})
+}
+
+// testAccInfobloxZoneAuthSetAllowTransfer - changes the allow_transfer of a zone outside Terraform
+func testAccInfobloxZoneAuthSetAllowTransfer(testFQDN, address string) error {
+	client := testAccProvider.Meta().(*providerMeta).client
+	zones := new([]zoneauth.DNSZone)
+	if err := searchSingleObject(client, "zone_auth", map[string]string{"fqdn": testFQDN}, []string{"fqdn"}, zones); err != nil {
+		return err
+	}
+	zone := zoneauth.DNSZone{
+		Reference:     (*zones)[0].Reference,
+		AllowTransfer: []interface{}{zoneauth.AddressAC{StructType: "addressac", Address: address, Permission: "ALLOW"}},
+	}
+	return client.Do(zoneauth.NewUpdate(zone, []string{"fqdn"}))
+}
+
+// testAccInfobloxZoneAuthSetUseDNSSecKeyParams - switches a zone between its own and the grid DNSSEC key parameters outside Terraform
+func testAccInfobloxZoneAuthSetUseDNSSecKeyParams(testFQDN string, useDNSSecKeyParams bool) error {
+	client := testAccProvider.Meta().(*providerMeta).client
+	zones := new([]zoneauth.DNSZone)
+	if err := searchSingleObject(client, "zone_auth", map[string]string{"fqdn": testFQDN}, []string{"fqdn"}, zones); err != nil {
+		return err
+	}
+	zone := zoneauth.DNSZone{
+		Reference:           (*zones)[0].Reference,
+		UseDDNSSecKeyParams: &useDNSSecKeyParams,
+	}
+	return client.Do(zoneauth.NewUpdate(zone, []string{"fqdn"}))
+}
+
 func testAccInfobloxZoneAuthExists(testFQDN, resourceName string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 
@@ -174,7 +337,7 @@ func testAccInfobloxZoneAuthExists(testFQDN, resourceName string) resource.TestC
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("Infoblox Zone Auth resource ID not set in resources ")
 		}
-		client := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		client := testAccProvider.Meta().(*providerMeta).client
 		getAllAPI := zoneauth.NewGetAllZones()
 
 		err := client.Do(getAllAPI)
@@ -192,7 +355,7 @@ func testAccInfobloxZoneAuthExists(testFQDN, resourceName string) resource.TestC
 
 func testAccInfobloxZoneAuthCheckDestroy(state *terraform.State, fqdn string) error {
 
-	infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "infoblox_zone_auth" {
@@ -459,3 +622,74 @@ allow_update = [
   permission = "ALLOW"
 },]}`, testFQDN)
 }
+
+func testAccInfobloxZoneAuthDNSSecTemplate(testFQDN, kskAlgorithm string, zskRollover int) string {
+	return fmt.Sprintf(`
+resource "infoblox_zone_auth" "acctest" {
+fqdn = "%s"
+comment = "Signed zone"
+dnssec {
+  ksk_algorithm {
+    algorithm = "%s"
+    size = 2048
+  }
+  zsk_rollover = %d
+  next_secure_type = "NSEC"
+}
+}`, testFQDN, kskAlgorithm, zskRollover)
+}
+
+func testAccInfobloxZoneAuthMinimalTemplate(testFQDN string) string {
+	return fmt.Sprintf(`
+resource "infoblox_zone_auth" "acctest" {
+fqdn = "%s"
+comment = "Created a zone"
+}`, testFQDN)
+}
+
+func testAccInfobloxZoneAuthAccessControlTemplate(testFQDN, namedACL string) string {
+	return fmt.Sprintf(`
+resource "infoblox_named_acl" "acctest" {
+name = "%s"
+access_list {
+  type = "addressac"
+  address = "10.10.0.0/16"
+  permission = "ALLOW"
+}
+}
+
+resource "infoblox_zone_auth" "acctest" {
+fqdn = "%s"
+comment = "Zone with access controls"
+allow_query {
+  type = "namedacl"
+  name = "${infoblox_named_acl.acctest.name}"
+}
+allow_query {
+  type = "addressac"
+  address = "192.168.200.0/24"
+  permission = "DENY"
+}
+use_allow_query = true
+allow_transfer {
+  type = "tsigac"
+  tsig_key = "0jnu3SdsMvzzlmTDPYRceA=="
+  tsig_key_alg = "HMAC-SHA256"
+  tsig_key_name = "transfer.key"
+  use_tsig_key_name = false
+}
+use_allow_transfer = true
+allow_update {
+  type = "addressac"
+  address = "192.168.201.10"
+  permission = "ALLOW"
+}
+use_allow_update = true
+allow_update_forwarding = true
+update_forwarding {
+  type = "namedacl"
+  name = "${infoblox_named_acl.acctest.name}"
+}
+use_allow_update_forwarding = true
+}`, namedACL, testFQDN)
+}
@@ -15,6 +15,9 @@ func resourceZoneDelegated() *schema.Resource {
 		Read:   resourceZoneDelegatedRead,
 		Update: resourceZoneDelegateUpdate,
 		Delete: resourceZoneDelegatedDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("zone_delegated", "fqdn", "view"),
+		},
 		Schema: map[string]*schema.Schema{
 			"reference": {
 				Type:     schema.TypeString,
@@ -74,12 +77,13 @@ func resourceZoneDelegated() *schema.Resource {
 				Description: "NameServer group for this zone",
 				Optional:    true,
 			},
+			"extattrs": util.ExtAttrsSchema(),
 		},
 	}
 }
 
 func resourceZoneDelegatedCreate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var createZoneDelegated zonedelegated.ZoneDelegated
 
 	if v, ok := d.GetOk("fqdn"); ok {
@@ -129,11 +133,16 @@ func resourceZoneDelegatedCreate(d *schema.ResourceData, m interface{}) error {
 		createZoneDelegated.NsGroup = v.(string)
 	}
 
+	createZoneDelegated.ExtAttrs = buildExtAttrs(d, m)
+
 	createZoneDeletagedAPI := zonedelegated.NewCreate(createZoneDelegated)
 	errCreate := infobloxClient.Do(createZoneDeletagedAPI)
 	if errCreate != nil {
 		return fmt.Errorf("Error creating Zone Delegated %s", errCreate.Error())
 	}
+	if skyinfoblox.IsConflict(createZoneDeletagedAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the delegated zone already exists, import it rather than creating it - %s", createZoneDeletagedAPI.Error())
+	}
 	if createZoneDeletagedAPI.StatusCode() != http.StatusCreated {
 		return fmt.Errorf("Error creating Zone Delegated %s", *createZoneDeletagedAPI.ResponseObject().(*string))
 	}
@@ -143,23 +152,28 @@ func resourceZoneDelegatedCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceZoneDelegatedRead(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var readZoneDelegated zonedelegated.ZoneDelegated
-	returnFields := []string{"address", "comment", "fqdn", "disable", "zone_format", "delegate_to", "delegated_ttl", "locked", "use_delegated_ttl", "ns_group"}
+	returnFields := []string{"address", "comment", "fqdn", "disable", "zone_format", "delegate_to", "delegated_ttl", "locked", "use_delegated_ttl", "ns_group", "view", "extattrs"}
 	readAPI := zonedelegated.NewGet(d.Id(), returnFields)
 	readErr := infobloxClient.Do(readAPI)
 	if readErr != nil {
 		return fmt.Errorf("Could not read the resource %s", readErr.Error())
 	}
+	if skyinfoblox.IsNotFound(readAPI.Error()) {
+		d.SetId("")
+		return nil
+	}
 
 	if readAPI.StatusCode() != http.StatusOK {
-		return fmt.Errorf("Could not read the resource %s", *readAPI.ResponseObject().(*string))
+		return fmt.Errorf("Could not read the resource %s", readAPI.Error())
 	}
 	readZoneDelegated = *readAPI.ResponseObject().(*zonedelegated.ZoneDelegated)
 	d.SetId(readZoneDelegated.Ref)
+	d.Set("reference", readZoneDelegated.Ref)
 	d.Set("comment", readZoneDelegated.Comment)
 	d.Set("view", readZoneDelegated.View)
-	d.Set("delegate_to", readZoneDelegated.DelegateTo)
+	d.Set("delegate_to", util.BuildExternalServersListFromIBX(readZoneDelegated.DelegateTo))
 	d.Set("delegated_ttl", readZoneDelegated.DelegatedTTL)
 	d.Set("disable", readZoneDelegated.Disable)
 	d.Set("fqdn", readZoneDelegated.Fqdn)
@@ -167,11 +181,12 @@ func resourceZoneDelegatedRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("ns_group", readZoneDelegated.NsGroup)
 	d.Set("use_delegated_ttl", readZoneDelegated.UseDelegatedTTL)
 	d.Set("zone_format", readZoneDelegated.ZoneFormat)
+	d.Set("extattrs", flattenExtAttrs(d, m, readZoneDelegated.ExtAttrs))
 	return nil
 }
 
 func resourceZoneDelegateUpdate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var updateZoneDelegated zonedelegated.ZoneDelegated
 	var hasChange bool
 	if d.HasChange("comment") {
@@ -236,6 +251,11 @@ func resourceZoneDelegateUpdate(d *schema.ResourceData, m interface{}) error {
 		hasChange = true
 	}
 
+	if d.HasChange("extattrs") {
+		updateZoneDelegated.ExtAttrs = buildExtAttrs(d, m)
+		hasChange = true
+	}
+
 	if hasChange {
 		updateZoneDelegatedAPI := zonedelegated.NewUpdate(d.Id(), updateZoneDelegated)
 		updateZoneErr := infobloxClient.Do(updateZoneDelegatedAPI)
@@ -253,7 +273,7 @@ func resourceZoneDelegateUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceZoneDelegatedDelete(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	deleteZoneDelegatedAPI := zonedelegated.NewDelete(d.Id())
 	deleteErr := infobloxClient.Do(deleteZoneDelegatedAPI)
 	if deleteErr != nil {
@@ -5,7 +5,6 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/zonedelegated"
 	"testing"
 )
@@ -52,7 +51,7 @@ func testAccInfobloxZoneDelegatedExists(zoneFqdn, resourceName string) resource.
 			return fmt.Errorf("Infoblox Zone Delegated resource ID not set in resources ")
 		}
 		fields := []string{"fqdn"}
-		client := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		client := testAccProvider.Meta().(*providerMeta).client
 		getAllAPI := zonedelegated.NewGetAll(fields)
 		err := client.Do(getAllAPI)
 		if err != nil {
@@ -68,7 +67,7 @@ func testAccInfobloxZoneDelegatedExists(zoneFqdn, resourceName string) resource.
 }
 
 func testAccInfobloxZoneDelegatedCheckDestroy(state *terraform.State, zoneFqdn string) error {
-	infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "infoblox_zone_delegated" {
 			continue
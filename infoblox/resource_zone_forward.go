@@ -15,6 +15,9 @@ func resourceZoneForward() *schema.Resource {
 		Read:   resourceZoneForwardRead,
 		Update: resourceZoneForwardUpdate,
 		Delete: resourceZoneForwardDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("zone_forward", "fqdn", "view"),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"address": {
@@ -122,13 +125,14 @@ func resourceZoneForward() *schema.Resource {
 				Computed:     true,
 				ForceNew:     true,
 			},
+			"extattrs": util.ExtAttrsSchema(),
 		},
 	}
 }
 
 func resourceZoneForwardCreate(d *schema.ResourceData, m interface{}) error {
 
-	ibxClient := m.(*skyinfoblox.InfobloxClient)
+	ibxClient := m.(*providerMeta).client
 	var zone zoneforward.ZoneForward
 
 	if v, ok := d.GetOk("comment"); ok && v != "" {
@@ -171,16 +175,21 @@ func resourceZoneForwardCreate(d *schema.ResourceData, m interface{}) error {
 		zone.ZoneFormat = v.(string)
 	}
 
+	zone.ExtAttrs = buildExtAttrs(d, m)
+
 	api := zoneforward.NewCreate(zone)
 	err := ibxClient.Do(api)
 
 	if err != nil {
-		return fmt.Errorf(fmt.Sprintf("Error creating a new forward zone, error:\n%s\n", err))
+		return fmt.Errorf("Error creating a new forward zone, error:\n%s\n", err)
 	}
 
+	if skyinfoblox.IsConflict(api.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the forward zone already exists, import it rather than creating it - %s", api.Error())
+	}
 	if api.StatusCode() != http.StatusCreated {
-		return fmt.Errorf(fmt.Sprintf("Error creating a new forward zone, status: %d, error:\n%s\n",
-			api.StatusCode(), api.RawResponse()))
+		return fmt.Errorf("Error creating a new forward zone, status: %d, error:\n%s\n",
+			api.StatusCode(), api.RawResponse())
 	}
 
 	ref := *api.ResponseObject().(*string)
@@ -190,11 +199,11 @@ func resourceZoneForwardCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func zoneForwardReturnFields() []string {
-	return []string{"address", "comment", "disable", "display_domain", "dns_fqdn", "forward_to", "forwarders_only", "forwarding_servers", "fqdn", "locked", "locked_by", "mask_prefix", "ms_ad_integrated", "ms_ddns_mode", "ms_managed", "ms_read_only", "ms_sync_master_name", "parent", "prefix", "using_srg_associations", "view", "zone_format"}
+	return []string{"address", "comment", "disable", "display_domain", "dns_fqdn", "forward_to", "forwarders_only", "forwarding_servers", "fqdn", "locked", "locked_by", "mask_prefix", "ms_ad_integrated", "ms_ddns_mode", "ms_managed", "ms_read_only", "ms_sync_master_name", "parent", "prefix", "using_srg_associations", "view", "zone_format", "extattrs"}
 }
 
 func resourceZoneForwardRead(d *schema.ResourceData, m interface{}) error {
-	ibxClient := m.(*skyinfoblox.InfobloxClient)
+	ibxClient := m.(*providerMeta).client
 	resourceReference := d.Id()
 	if resourceReference == "" {
 		return nil
@@ -204,6 +213,10 @@ func resourceZoneForwardRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return fmt.Errorf("Could not read the resource %s", err.Error())
 	}
+	if skyinfoblox.IsNotFound(api.Error()) {
+		d.SetId("")
+		return nil
+	}
 	if api.StatusCode() != http.StatusOK {
 		return fmt.Errorf("Could not read the resource %s", string(api.RawResponse()))
 	}
@@ -216,9 +229,9 @@ func resourceZoneForwardRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("disable", zone.Disable)
 	d.Set("display_domain", zone.DisplayDomain)
 	d.Set("dns_fqdn", zone.DNSFqdn)
-	d.Set("forward_to", zone.ForwardTo)
+	d.Set("forward_to", util.BuildExternalServersListFromIBX(zone.ForwardTo))
 	d.Set("forwarders_only", zone.ForwardersOnly)
-	d.Set("forwarding_servers", zone.ForwardingServers)
+	d.Set("forwarding_servers", util.BuildForwardingMemberServerListFromIBX(zone.ForwardingServers))
 	d.Set("fqdn", zone.Fqdn)
 	d.Set("locked", zone.Locked)
 	d.Set("locked_by", zone.LockedBy)
@@ -228,12 +241,13 @@ func resourceZoneForwardRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("prefix", zone.Prefix)
 	d.Set("view", zone.View)
 	d.Set("zone_format", zone.ZoneFormat)
+	d.Set("extattrs", flattenExtAttrs(d, m, zone.ExtAttrs))
 
 	return nil
 }
 
 func resourceZoneForwardUpdate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var updatedZone zoneforward.ZoneForward
 	hasChanges := false
 	returnFields := zoneForwardReturnFields()
@@ -301,6 +315,11 @@ func resourceZoneForwardUpdate(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
+	if d.HasChange("extattrs") {
+		updatedZone.ExtAttrs = buildExtAttrs(d, m)
+		hasChanges = true
+	}
+
 	if hasChanges == true {
 		updateAPI := zoneforward.NewUpdate(updatedZone, returnFields)
 		err := infobloxClient.Do(updateAPI)
@@ -322,7 +341,7 @@ func resourceZoneForwardUpdate(d *schema.ResourceData, m interface{}) error {
 func resourceZoneForwardDelete(d *schema.ResourceData, m interface{}) error {
 	returnFields := []string{"fqdn"}
 
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	resourceReference := d.Id()
 	getAPI := zoneforward.NewGet(resourceReference, returnFields)
 
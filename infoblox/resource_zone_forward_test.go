@@ -5,7 +5,6 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/zoneforward"
 	"net/http"
 	"os"
@@ -82,7 +81,7 @@ func TestAccInfobloxZoneForwardBasic(t *testing.T) {
 
 func zoneForwardCheckDestroy(state *terraform.State, fqdn string) error {
 
-	infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "infoblox_zone_forward" {
@@ -145,7 +144,7 @@ func testZoneForwardExists(testFQDN, resourceName string) resource.TestCheckFunc
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("Infoblox Zone Auth resource ID not set in resources ")
 		}
-		client := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		client := testAccProvider.Meta().(*providerMeta).client
 		api := zoneforward.NewGetAll()
 		err := client.Do(api)
 		if err != nil {
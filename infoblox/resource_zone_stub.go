@@ -15,6 +15,9 @@ func resourceZoneStub() *schema.Resource {
 		Read:   resourceZoneStubRead,
 		Update: resourceZoneStubUpdate,
 		Delete: resourceZoneStubDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateFunc("zone_stub", "fqdn", "view"),
+		},
 		Schema: map[string]*schema.Schema{
 			"Reference": {
 				Type:     schema.TypeString,
@@ -93,12 +96,13 @@ func resourceZoneStub() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: util.CheckLeadingTrailingSpaces,
 			},
+			"extattrs": util.ExtAttrsSchema(),
 		},
 	}
 }
 
 func resourceZoneStubCreate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var createZoneStub zonestub.ZoneStub
 
 	if v, ok := d.GetOk("comment"); ok {
@@ -162,11 +166,16 @@ func resourceZoneStubCreate(d *schema.ResourceData, m interface{}) error {
 	if v, ok := d.GetOk("view"); ok {
 		createZoneStub.View = v.(string)
 	}
+
+	createZoneStub.ExtAttrs = buildExtAttrs(d, m)
 	createZoneStubAPI := zonestub.NewCreate(createZoneStub)
 	createZoneStubErr := infobloxClient.Do(createZoneStubAPI)
 	if createZoneStubErr != nil {
 		return fmt.Errorf("Infoblox Error creating the Stub Zone : %s", createZoneStubErr.Error())
 	}
+	if skyinfoblox.IsConflict(createZoneStubAPI.Error()) {
+		return fmt.Errorf("Infoblox Create Error: the stub zone already exists, import it rather than creating it - %s", createZoneStubAPI.Error())
+	}
 	if createZoneStubAPI.StatusCode() != http.StatusCreated {
 		return fmt.Errorf("Infoblox Zone Create Error: Invalid HTTP response code %d returned - response %s", createZoneStubAPI.StatusCode(), *createZoneStubAPI.ResponseObject().(*string))
 	}
@@ -176,21 +185,26 @@ func resourceZoneStubCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceZoneStubRead(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var readZoneStub zonestub.ZoneStub
 	zoneReadAPI := zonestub.NewGet(d.Id(), returnZoneStubFields())
 	readErr := infobloxClient.Do(zoneReadAPI)
 	if readErr != nil {
-
+		return fmt.Errorf("Infoblox Zone Read Error: %+v", readErr)
+	}
+	if skyinfoblox.IsNotFound(zoneReadAPI.Error()) {
+		d.SetId("")
+		return nil
 	}
 
 	if zoneReadAPI.StatusCode() != http.StatusOK {
-		return fmt.Errorf("Infoblox Zone Create Error: Invalid HTTP response code %d returned - response %s", zoneReadAPI.StatusCode(), zoneReadAPI.ResponseObject().(*string))
+		return fmt.Errorf("Infoblox Zone Read Error: Invalid HTTP response code %d returned - response %s", zoneReadAPI.StatusCode(), zoneReadAPI.Error())
 
 	}
 
 	readZoneStub = *zoneReadAPI.ResponseObject().(*zonestub.ZoneStub)
 	d.SetId(readZoneStub.Ref)
+	d.Set("Reference", readZoneStub.Ref)
 	d.Set("comment", readZoneStub.Comment)
 	d.Set("disable", readZoneStub.Disable)
 	d.Set("locked", readZoneStub.Locked)
@@ -204,11 +218,12 @@ func resourceZoneStubRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("stub_members", util.BuildMemberServerListFromIBX(readZoneStub.StubMembers))
 	d.Set("zoneformat", readZoneStub.ZoneFormat)
 	d.Set("view", readZoneStub.View)
+	d.Set("extattrs", flattenExtAttrs(d, m, readZoneStub.ExtAttrs))
 	return nil
 }
 
 func resourceZoneStubUpdate(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	var updateStubZone zonestub.ZoneStub
 	updateStubZone.Ref = d.Id()
 	if d.HasChange("comment") {
@@ -278,6 +293,10 @@ func resourceZoneStubUpdate(d *schema.ResourceData, m interface{}) error {
 		_, newView := d.GetChange("view")
 		updateStubZone.ZoneFormat = newView.(string)
 	}
+
+	if d.HasChange("extattrs") {
+		updateStubZone.ExtAttrs = buildExtAttrs(d, m)
+	}
 	updateStubZoneAPI := zonestub.NewUpdate(updateStubZone)
 	updateStubZoneErr := infobloxClient.Do(updateStubZoneAPI)
 	if updateStubZoneErr != nil {
@@ -285,14 +304,14 @@ func resourceZoneStubUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if updateStubZoneAPI.StatusCode() != http.StatusOK {
-		return fmt.Errorf("Error updating the Stub Zone : %s - %s ", updateStubZoneAPI.StatusCode(), updateStubZoneAPI.ResponseObject().(string))
+		return fmt.Errorf("Error updating the Stub Zone : %d - %s ", updateStubZoneAPI.StatusCode(), updateStubZoneAPI.Error())
 	}
 
 	return resourceZoneStubRead(d, m)
 }
 
 func resourceZoneStubDelete(d *schema.ResourceData, m interface{}) error {
-	infobloxClient := m.(*skyinfoblox.InfobloxClient)
+	infobloxClient := m.(*providerMeta).client
 	deleteAPI := zonestub.NewDelete(d.Id())
 	deleteErr := infobloxClient.Do(deleteAPI)
 	if deleteErr != nil {
@@ -300,13 +319,13 @@ func resourceZoneStubDelete(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if deleteAPI.StatusCode() != http.StatusOK {
-		return fmt.Errorf("Infoblox Zone Delete Error: %s - %s", deleteAPI.StatusCode(), deleteAPI.ResponseObject().(*string))
+		return fmt.Errorf("Infoblox Zone Delete Error: %d - %s", deleteAPI.StatusCode(), deleteAPI.Error())
 	}
 	d.SetId("")
 	return nil
 }
 
 func returnZoneStubFields() []string {
-	return []string{"comment", "disable", "locked", "disable_forwarding", "external_ns_group", "fqdn", "mask_prefix", "ns_group", "prefix", "stub_from", "stub_members", "zone_format", "view"}
+	return []string{"comment", "disable", "locked", "disable_forwarding", "external_ns_group", "fqdn", "mask_prefix", "ns_group", "prefix", "stub_from", "stub_members", "zone_format", "view", "extattrs"}
 
 }
@@ -5,7 +5,6 @@ import (
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/sky-uk/skyinfoblox"
 	"github.com/sky-uk/skyinfoblox/api/zoneauth"
 	"github.com/sky-uk/skyinfoblox/api/zonestub"
 	"strconv"
@@ -57,7 +56,7 @@ func testAccInfobloxZoneStubExists(testFQDN, resourceName string) resource.TestC
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("Infoblox Zone Stub resource ID not set in resources ")
 		}
-		client := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+		client := testAccProvider.Meta().(*providerMeta).client
 		getAllAPI := zonestub.NewGetAll([]string{"fqdn", "comment"})
 
 		err := client.Do(getAllAPI)
@@ -75,7 +74,7 @@ func testAccInfobloxZoneStubExists(testFQDN, resourceName string) resource.TestC
 
 func testAccInfobloxZoneStubCheckDestroy(state *terraform.State, fqdn string) error {
 
-	infobloxClient := testAccProvider.Meta().(*skyinfoblox.InfobloxClient)
+	infobloxClient := testAccProvider.Meta().(*providerMeta).client
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "infoblox_zone_stub" {
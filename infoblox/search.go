@@ -0,0 +1,58 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/skyinfoblox/api"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// searchSingleObject - searches for objects of objectType matching searchFields and fails unless exactly one matches.
+// The response object must be a pointer to a slice of the object type and holds the match on success.
+func searchSingleObject(infobloxClient *skyinfoblox.InfobloxClient, objectType string, searchFields map[string]string, returnFields []string, responseObject interface{}) error {
+	searchAPI := api.NewSearch(objectType, searchFields, returnFields, responseObject)
+	err := infobloxClient.Do(searchAPI)
+	if err != nil {
+		return err
+	}
+	if searchAPI.StatusCode() != http.StatusOK {
+		return fmt.Errorf("Invalid HTTP response code %d returned - response %s", searchAPI.StatusCode(), string(searchAPI.RawResponse()))
+	}
+
+	matches := reflect.ValueOf(responseObject).Elem().Len()
+	if matches == 0 {
+		return fmt.Errorf("no %s found matching %s", objectType, describeSearchFields(searchFields))
+	}
+	if matches > 1 {
+		return fmt.Errorf("%d %s objects match %s, the search must match a single object", matches, objectType, describeSearchFields(searchFields))
+	}
+	return nil
+}
+
+func describeSearchFields(searchFields map[string]string) string {
+	if len(searchFields) == 0 {
+		return "no filters"
+	}
+	fields := make([]string, 0, len(searchFields))
+	for field, value := range searchFields {
+		fields = append(fields, fmt.Sprintf("%s=%s", field, value))
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ", ")
+}
+
+// dataSourceSearchFields - returns the WAPI search fields for the filter attributes set on a data source.
+// filters maps the name of each filter attribute to the WAPI field it searches on.
+func dataSourceSearchFields(d *schema.ResourceData, filters map[string]string) map[string]string {
+	searchFields := make(map[string]string)
+	for attribute, field := range filters {
+		if v, ok := d.GetOk(attribute); ok {
+			searchFields[field] = v.(string)
+		}
+	}
+	return searchFields
+}
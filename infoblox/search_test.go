@@ -0,0 +1,39 @@
+package infoblox
+
+import (
+	"context"
+	"github.com/sky-uk/skyinfoblox/api/records"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/wapitest"
+	"testing"
+)
+
+func TestSearchSingleObject(t *testing.T) {
+	server := wapitest.NewServer()
+	defer server.Close()
+	for _, name := range []string{"web01.example.com", "web02.example.com"} {
+		if _, err := server.Create("record:a", map[string]interface{}{"name": name, "ipv4addr": "10.0.0.10"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	meta, err := configureClient(testProviderResourceData(t, server.URL, nil), context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	infobloxClient := meta.(*providerMeta).client
+
+	found := new([]records.ARecord)
+	err = searchSingleObject(infobloxClient, "record:a", map[string]string{"name": "web01.example.com"}, []string{"name"}, found)
+	if err != nil || len(*found) != 1 || (*found)[0].Name != "web01.example.com" {
+		t.Fatalf("Expected web01.example.com to be found, got %v - %v", *found, err)
+	}
+
+	err = searchSingleObject(infobloxClient, "record:a", map[string]string{"ipv4addr": "10.0.0.10"}, nil, new([]records.ARecord))
+	if err == nil || err.Error() != "2 record:a objects match ipv4addr=10.0.0.10, the search must match a single object" {
+		t.Fatalf("Expected the search to fail on several objects, got %v", err)
+	}
+
+	err = searchSingleObject(infobloxClient, "record:a", map[string]string{"name": "web03.example.com", "view": "default"}, nil, new([]records.ARecord))
+	if err == nil || err.Error() != "no record:a found matching name=web03.example.com, view=default" {
+		t.Fatalf("Expected the search to fail on no object, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package infoblox
+
+import (
+	"github.com/sky-uk/skyinfoblox"
+	"log"
+	"sync"
+)
+
+// configuredClients - the clients returned by every configured provider, their sessions are ended by Logout
+var configuredClients = struct {
+	sync.Mutex
+	clients []*skyinfoblox.InfobloxClient
+}{}
+
+// registerClient - records a configured client so its session is ended on teardown
+func registerClient(infobloxClient *skyinfoblox.InfobloxClient) {
+	configuredClients.Lock()
+	defer configuredClients.Unlock()
+	configuredClients.clients = append(configuredClients.clients, infobloxClient)
+}
+
+// Logout - ends the grid sessions of every configured provider, to be called once the plugin stops serving
+func Logout() {
+	configuredClients.Lock()
+	defer configuredClients.Unlock()
+	for _, infobloxClient := range configuredClients.clients {
+		if err := infobloxClient.Logout(); err != nil {
+			log.Printf("[WARN] Could not log out of %s: %s", infobloxClient.URL, err)
+		}
+	}
+	configuredClients.clients = nil
+}
@@ -0,0 +1,55 @@
+package infoblox
+
+import (
+	"context"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/wapitest"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLogout(t *testing.T) {
+	fake := wapitest.NewServer()
+	defer fake.Close()
+	var mutex sync.Mutex
+	logouts := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/logout") {
+			cookie, err := r.Cookie("ibapauth")
+			mutex.Lock()
+			if err == nil {
+				logouts = append(logouts, cookie.Value)
+			} else {
+				logouts = append(logouts, "")
+			}
+			mutex.Unlock()
+		}
+		fake.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	// only the providers configured by this test are logged out
+	configuredClients.Lock()
+	configuredClients.clients = nil
+	configuredClients.Unlock()
+	for i := 0; i < 2; i++ {
+		if _, err := configureClient(testProviderResourceData(t, server.URL, nil), context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	Logout()
+	if len(logouts) != 2 || logouts[0] == "" || logouts[1] == "" || logouts[0] == logouts[1] {
+		t.Fatalf("Expected each provider to end its own session, got the logouts %v", logouts)
+	}
+	if len(configuredClients.clients) != 0 {
+		t.Fatalf("Expected the clients to be forgotten once logged out, %d left", len(configuredClients.clients))
+	}
+
+	Logout()
+	if len(logouts) != 2 {
+		t.Fatalf("Expected a second Logout to send nothing, got the logouts %v", logouts)
+	}
+}
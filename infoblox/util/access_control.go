@@ -4,19 +4,20 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/sky-uk/skyinfoblox/api/zoneauth"
+	"strings"
 )
 
 // AccessControlSchema - returns the schema for an access control
 func AccessControlSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:        schema.TypeList,
-		Description: "Determines whether dynamic DNS updates are allowed from a named ACL, or from a list of IPv4/IPv6 addresses, networks, and TSIG keys for the hosts.",
+		Description: "An ordered list of IPv4/IPv6 addresses, networks and TSIG keys, or of named ACLs, the access is allowed or denied to",
 		Optional:    true,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"type": {
 					Type:         schema.TypeString,
-					Description:  "Specifies the type of struct we're passing",
+					Description:  "Specifies the type of struct we're passing: addressac, tsigac or namedacl",
 					Optional:     true,
 					ValidateFunc: ValidateAcType,
 				},
@@ -55,6 +56,12 @@ func AccessControlSchema() *schema.Schema {
 					Description: "Use flag for: tsig_key_name",
 					Optional:    true,
 				},
+				"name": {
+					Type:         schema.TypeString,
+					Description:  "The name of the named ACL, for the namedacl type",
+					Optional:     true,
+					ValidateFunc: CheckLeadingTrailingSpaces,
+				},
 			},
 		},
 	}
@@ -63,8 +70,8 @@ func AccessControlSchema() *schema.Schema {
 // ValidateAcType - validates if the access control type is correct
 func ValidateAcType(v interface{}, k string) (ws []string, errors []error) {
 	acType := v.(string)
-	if acType != "addressac" && acType != "tsigac" {
-		errors = append(errors, fmt.Errorf("%q must be one of addressac or tsigac", k))
+	if acType != "addressac" && acType != "tsigac" && acType != "namedacl" {
+		errors = append(errors, fmt.Errorf("%q must be one of addressac, tsigac or namedacl", k))
 	}
 	return
 }
@@ -78,7 +85,8 @@ func ValidateAddressAcPermission(v interface{}, k string) (ws []string, errors [
 	return
 }
 
-// BuildAcList - builds a list of access controls
+// BuildAcList - builds a list of access controls. Named ACLs are listed by name, as a NamedACL, and must be
+// replaced by their reference before the list is sent to WAPI
 func BuildAcList(acList []interface{}) []interface{} {
 
 	builtAc := make([]interface{}, len(acList))
@@ -103,7 +111,43 @@ func BuildAcList(acList []interface{}) []interface{} {
 				tsigAccessControl.UseTsigKeyName = &useTSIGKeyName
 				builtAc[idx] = tsigAccessControl
 			}
+			if permission["type"] == "namedacl" {
+				builtAc[idx] = NamedACL(permission["name"].(string))
+			}
 		}
 	}
 	return builtAc
 }
+
+// NamedACL - the name of a named ACL in a list of access controls
+type NamedACL string
+
+// BuildAcListFromIBX - builds a list of access controls for terraform given the
+// corresponding list of address and TSIG access control structs, and named ACL references, from IBX
+func BuildAcListFromIBX(IBXAcList []interface{}) []map[string]interface{} {
+	acList := make([]map[string]interface{}, 0)
+	for _, value := range IBXAcList {
+		if ref, ok := value.(string); ok && IsReference("namedacl", ref) {
+			acList = append(acList, map[string]interface{}{"type": "namedacl", "name": ref[strings.LastIndex(ref, ":")+1:]})
+			continue
+		}
+		IBXAc, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ac := make(map[string]interface{})
+		if v, ok := IBXAc["_struct"].(string); ok {
+			ac["type"] = v
+		}
+		for _, key := range []string{"address", "permission", "tsig_key", "tsig_key_alg", "tsig_key_name"} {
+			if v, ok := IBXAc[key].(string); ok {
+				ac[key] = v
+			}
+		}
+		if v, ok := IBXAc["use_tsig_key_name"].(bool); ok {
+			ac["use_tsig_key_name"] = v
+		}
+		acList = append(acList, ac)
+	}
+	return acList
+}
@@ -0,0 +1,54 @@
+package util
+
+import (
+	"github.com/sky-uk/skyinfoblox/api/zoneauth"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildAcListFromIBX(t *testing.T) {
+	IBXAcList := []interface{}{
+		map[string]interface{}{
+			"_struct":    "addressac",
+			"address":    "10.0.0.1",
+			"permission": "ALLOW",
+		},
+		map[string]interface{}{
+			"_struct":           "tsigac",
+			"tsig_key":          "0jnu3SdsMvzzlmTDPYRceA==",
+			"tsig_key_alg":      "HMAC-SHA256",
+			"tsig_key_name":     "example.com",
+			"use_tsig_key_name": true,
+		},
+	}
+
+	acList := []map[string]interface{}{
+		{
+			"type":       "addressac",
+			"address":    "10.0.0.1",
+			"permission": "ALLOW",
+		},
+		{
+			"type":              "tsigac",
+			"tsig_key":          "0jnu3SdsMvzzlmTDPYRceA==",
+			"tsig_key_alg":      "HMAC-SHA256",
+			"tsig_key_name":     "example.com",
+			"use_tsig_key_name": true,
+		},
+	}
+
+	assert.Equal(t, acList, BuildAcListFromIBX(IBXAcList))
+}
+
+func TestBuildAcListNamedACL(t *testing.T) {
+	acList := []interface{}{
+		map[string]interface{}{"type": "namedacl", "name": "internal"},
+		map[string]interface{}{"type": "addressac", "address": "10.0.0.1", "permission": "DENY"},
+	}
+	builtAc := BuildAcList(acList)
+	assert.Equal(t, NamedACL("internal"), builtAc[0])
+	assert.Equal(t, zoneauth.AddressAC{StructType: "addressac", Address: "10.0.0.1", Permission: "DENY"}, builtAc[1])
+
+	IBXAcList := []interface{}{"namedacl/b25lLm5hbWVkX2FjbCRpbnRlcm5hbA:internal"}
+	assert.Equal(t, []map[string]interface{}{{"type": "namedacl", "name": "internal"}}, BuildAcListFromIBX(IBXAcList))
+}
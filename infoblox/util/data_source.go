@@ -0,0 +1,37 @@
+package util
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// DataSourceSchema - returns a copy of a resource schema for use by a data source.
+// Every attribute becomes computed, apart from the filter attributes which stay optional
+// so they can be used to look the object up.
+func DataSourceSchema(resourceSchema map[string]*schema.Schema, filters ...string) map[string]*schema.Schema {
+	dataSourceSchema := computedSchema(resourceSchema)
+	for _, filter := range filters {
+		if attribute, ok := dataSourceSchema[filter]; ok {
+			attribute.Optional = true
+		}
+	}
+	return dataSourceSchema
+}
+
+func computedSchema(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	computed := make(map[string]*schema.Schema, len(resourceSchema))
+	for key, attribute := range resourceSchema {
+		computedAttribute := &schema.Schema{
+			Type:        attribute.Type,
+			Description: attribute.Description,
+			Computed:    true,
+			Elem:        attribute.Elem,
+			Set:         attribute.Set,
+			Sensitive:   attribute.Sensitive,
+		}
+		if elem, ok := attribute.Elem.(*schema.Resource); ok {
+			computedAttribute.Elem = &schema.Resource{Schema: computedSchema(elem.Schema)}
+		}
+		computed[key] = computedAttribute
+	}
+	return computed
+}
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDataSourceSchema(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: CheckLeadingTrailingSpaces,
+		},
+		"comment": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "a comment",
+		},
+		"members": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				},
+			},
+		},
+	}
+
+	dataSourceSchema := DataSourceSchema(resourceSchema, "name")
+
+	assert.True(t, dataSourceSchema["name"].Optional)
+	assert.True(t, dataSourceSchema["name"].Computed)
+	assert.False(t, dataSourceSchema["name"].Required)
+	assert.Nil(t, dataSourceSchema["name"].ValidateFunc)
+
+	assert.False(t, dataSourceSchema["comment"].Optional)
+	assert.True(t, dataSourceSchema["comment"].Computed)
+	assert.Nil(t, dataSourceSchema["comment"].Default)
+
+	members := dataSourceSchema["members"].Elem.(*schema.Resource).Schema
+	assert.True(t, members["name"].Computed)
+	assert.False(t, members["name"].Required)
+
+	assert.True(t, resourceSchema["name"].Required, "the resource schema must not be modified")
+}
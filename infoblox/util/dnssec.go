@@ -0,0 +1,297 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox/api/zoneauth"
+	"strconv"
+	"strings"
+)
+
+// dnssecAlgorithms - the number of each DNSSEC algorithm NIOS signs with, keyed by mnemonic
+var dnssecAlgorithms = map[string]uint8{
+	"RSAMD5":          1,
+	"DSA":             3,
+	"RSASHA1":         5,
+	"NSEC3DSA":        6,
+	"NSEC3RSASHA1":    7,
+	"RSASHA256":       8,
+	"RSASHA512":       10,
+	"ECDSAP256SHA256": 13,
+	"ECDSAP384SHA384": 14,
+}
+
+// DNSSecSchema - returns the schema for the DNSSEC key parameters of a zone. The zone is signed while it is set
+func DNSSecSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Signs the zone with DNSSEC using these key parameters. Removing it unsigns the zone",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ksk_algorithm": dnssecAlgorithmSchema("The algorithms and sizes of the key-signing keys"),
+				"zsk_algorithm": dnssecAlgorithmSchema("The algorithms and sizes of the zone-signing keys"),
+				"ksk_rollover": {
+					Type:         schema.TypeInt,
+					Description:  "The rollover period of the key-signing keys, in seconds",
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: ValidateUnsignedInteger,
+				},
+				"zsk_rollover": {
+					Type:         schema.TypeInt,
+					Description:  "The rollover period of the zone-signing keys, in seconds",
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: ValidateUnsignedInteger,
+				},
+				"zsk_rollover_mechanism": {
+					Type:         schema.TypeString,
+					Description:  "How zone-signing keys are rolled over: PRE_PUBLISH or DOUBLE_SIGN",
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: ValidateZskRolloverMechanism,
+				},
+				"enable_ksk_auto_rollover": {
+					Type:        schema.TypeBool,
+					Description: "If set, key-signing keys are rolled over automatically",
+					Optional:    true,
+					Default:     false,
+				},
+				"next_secure_type": {
+					Type:         schema.TypeString,
+					Description:  "The record proving the non existence of names: NSEC or NSEC3",
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: ValidateNextSecureType,
+				},
+				"nsec3_iterations": {
+					Type:         schema.TypeInt,
+					Description:  "The number of hash iterations of NSEC3 records",
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: ValidateUnsignedInteger,
+				},
+				"nsec3_salt_min_length": {
+					Type:         schema.TypeInt,
+					Description:  "The minimum length of the salt of NSEC3 records",
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: ValidateUnsignedInteger,
+				},
+				"nsec3_salt_max_length": {
+					Type:         schema.TypeInt,
+					Description:  "The maximum length of the salt of NSEC3 records",
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: ValidateUnsignedInteger,
+				},
+				"signature_expiration": {
+					Type:         schema.TypeInt,
+					Description:  "The validity period of signatures, in seconds",
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: ValidateUnsignedInteger,
+				},
+			},
+		},
+	}
+}
+
+func dnssecAlgorithmSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: description,
+		Optional:    true,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"algorithm": {
+					Type:         schema.TypeString,
+					Description:  "The algorithm, e.g. RSASHA256 or ECDSAP256SHA256",
+					Required:     true,
+					ValidateFunc: ValidateDNSSecAlgorithm,
+				},
+				"size": {
+					Type:         schema.TypeInt,
+					Description:  "The size of the keys, in bits",
+					Required:     true,
+					ValidateFunc: ValidateUnsignedInteger,
+				},
+			},
+		},
+	}
+}
+
+// ValidateDNSSecAlgorithm - checks the DNSSEC algorithm is one NIOS signs with
+func ValidateDNSSecAlgorithm(v interface{}, k string) (ws []string, errors []error) {
+	if _, ok := dnssecAlgorithms[v.(string)]; !ok {
+		errors = append(errors, fmt.Errorf("%q must be one of RSAMD5, DSA, RSASHA1, NSEC3DSA, NSEC3RSASHA1, RSASHA256, RSASHA512, ECDSAP256SHA256 or ECDSAP384SHA384", k))
+	}
+	return
+}
+
+// ValidateZskRolloverMechanism - checks the zone-signing key rollover mechanism is valid
+func ValidateZskRolloverMechanism(v interface{}, k string) (ws []string, errors []error) {
+	mechanism := v.(string)
+	if mechanism != "PRE_PUBLISH" && mechanism != "DOUBLE_SIGN" {
+		errors = append(errors, fmt.Errorf("%q must be one of PRE_PUBLISH or DOUBLE_SIGN", k))
+	}
+	return
+}
+
+// ValidateNextSecureType - checks the next secure type is valid
+func ValidateNextSecureType(v interface{}, k string) (ws []string, errors []error) {
+	nextSecureType := v.(string)
+	if nextSecureType != "NSEC" && nextSecureType != "NSEC3" {
+		errors = append(errors, fmt.Errorf("%q must be one of NSEC or NSEC3", k))
+	}
+	return
+}
+
+// BuildDNSSecKeyParamsFromT - builds the DNSSEC key parameters of a zone from the dnssec block of the template,
+// nil when there is none
+func BuildDNSSecKeyParamsFromT(dnssecFromT []interface{}) *zoneauth.DNSSecKeyParameters {
+	if len(dnssecFromT) == 0 || dnssecFromT[0] == nil {
+		return nil
+	}
+	dnssec := dnssecFromT[0].(map[string]interface{})
+	enableKskAutoRollover := dnssec["enable_ksk_auto_rollover"].(bool)
+	return &zoneauth.DNSSecKeyParameters{
+		KskAlgorithms:         buildDNSSecAlgorithmsFromT(dnssec["ksk_algorithm"].([]interface{})),
+		KskRollover:           uint(dnssec["ksk_rollover"].(int)),
+		ZskAlgorithms:         buildZskAlgorithmsFromT(dnssec["zsk_algorithm"].([]interface{})),
+		ZskRollover:           uint(dnssec["zsk_rollover"].(int)),
+		ZskRolloverMechanism:  dnssec["zsk_rollover_mechanism"].(string),
+		EnableKskAutoRollover: &enableKskAutoRollover,
+		NextSecureType:        dnssec["next_secure_type"].(string),
+		NSec3Iterations:       uint(dnssec["nsec3_iterations"].(int)),
+		NSec3SaltMinLength:    uint(dnssec["nsec3_salt_min_length"].(int)),
+		NSec3SaltMaxLength:    uint(dnssec["nsec3_salt_max_length"].(int)),
+		SignatureExpiration:   uint(dnssec["signature_expiration"].(int)),
+	}
+}
+
+func buildDNSSecAlgorithmsFromT(algorithmsFromT []interface{}) []zoneauth.DNSSecKeyAlgorithm {
+	var algorithms []zoneauth.DNSSecKeyAlgorithm
+	for _, value := range algorithmsFromT {
+		algorithm := value.(map[string]interface{})
+		algorithms = append(algorithms, zoneauth.DNSSecKeyAlgorithm{
+			Algorithm: algorithm["algorithm"].(string),
+			Size:      uint(algorithm["size"].(int)),
+		})
+	}
+	return algorithms
+}
+
+func buildZskAlgorithmsFromT(algorithmsFromT []interface{}) []zoneauth.ZskAlgorithm {
+	var algorithms []zoneauth.ZskAlgorithm
+	for _, algorithm := range buildDNSSecAlgorithmsFromT(algorithmsFromT) {
+		algorithms = append(algorithms, zoneauth.ZskAlgorithm(algorithm))
+	}
+	return algorithms
+}
+
+// BuildDNSSecFromIBX - builds the dnssec block of the template from the DNSSEC key parameters of a zone
+func BuildDNSSecFromIBX(IBXKeyParams *zoneauth.DNSSecKeyParameters) []map[string]interface{} {
+	if IBXKeyParams == nil {
+		return []map[string]interface{}{}
+	}
+	kskAlgorithms := make([]map[string]interface{}, 0)
+	for _, algorithm := range IBXKeyParams.KskAlgorithms {
+		kskAlgorithms = append(kskAlgorithms, map[string]interface{}{"algorithm": algorithm.Algorithm, "size": int(algorithm.Size)})
+	}
+	zskAlgorithms := make([]map[string]interface{}, 0)
+	for _, algorithm := range IBXKeyParams.ZskAlgorithms {
+		zskAlgorithms = append(zskAlgorithms, map[string]interface{}{"algorithm": algorithm.Algorithm, "size": int(algorithm.Size)})
+	}
+	dnssec := map[string]interface{}{
+		"ksk_algorithm":            kskAlgorithms,
+		"ksk_rollover":             int(IBXKeyParams.KskRollover),
+		"zsk_algorithm":            zskAlgorithms,
+		"zsk_rollover":             int(IBXKeyParams.ZskRollover),
+		"zsk_rollover_mechanism":   IBXKeyParams.ZskRolloverMechanism,
+		"enable_ksk_auto_rollover": false,
+		"next_secure_type":         IBXKeyParams.NextSecureType,
+		"nsec3_iterations":         int(IBXKeyParams.NSec3Iterations),
+		"nsec3_salt_min_length":    int(IBXKeyParams.NSec3SaltMinLength),
+		"nsec3_salt_max_length":    int(IBXKeyParams.NSec3SaltMaxLength),
+		"signature_expiration":     int(IBXKeyParams.SignatureExpiration),
+	}
+	if IBXKeyParams.EnableKskAutoRollover != nil {
+		dnssec["enable_ksk_auto_rollover"] = *IBXKeyParams.EnableKskAutoRollover
+	}
+	return []map[string]interface{}{dnssec}
+}
+
+// dsKeyStatuses - the statuses of the key-signing keys resolvers may validate the zone with. New keys aren't
+// published yet and withdrawn ones no longer are.
+var dsKeyStatuses = map[string]bool{"ACTIVE": true, "PUBLISHED": true}
+
+// BuildDSRecords - builds the DS records, in zone file format, the parent of a signed zone must publish for its
+// active or published key-signing keys. Their digests are SHA-256 ones.
+func BuildDSRecords(zone string, IBXKeys []zoneauth.DNSSecKey) ([]string, error) {
+	dsRecords := make([]string, 0)
+	for _, key := range IBXKeys {
+		if key.Type != "KSK" || !dsKeyStatuses[key.Status] {
+			continue
+		}
+		dsRecord, err := BuildDSRecord(zone, 257, key.Algorithm, key.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		dsRecords = append(dsRecords, dsRecord)
+	}
+	return dsRecords, nil
+}
+
+// BuildDSRecord - builds the DS record of a DNSKEY of a zone, see RFC 4034 section 5 and RFC 4509. The algorithm
+// is given by number or mnemonic and the public key is base64 encoded.
+func BuildDSRecord(zone string, flags uint16, algorithm, publicKey string) (string, error) {
+	algorithmNumber, ok := dnssecAlgorithms[algorithm]
+	if !ok {
+		number, err := strconv.ParseUint(algorithm, 10, 8)
+		if err != nil {
+			return "", fmt.Errorf("Unknown DNSSEC algorithm %s", algorithm)
+		}
+		algorithmNumber = uint8(number)
+	}
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("Invalid DNSSEC public key %s: %s", publicKey, err)
+	}
+
+	rdata := make([]byte, 4, 4+len(key))
+	binary.BigEndian.PutUint16(rdata, flags)
+	rdata[2] = 3
+	rdata[3] = algorithmNumber
+	rdata = append(rdata, key...)
+
+	zone = strings.TrimSuffix(strings.ToLower(zone), ".")
+	owner := make([]byte, 0, len(zone)+2)
+	for _, label := range strings.Split(zone, ".") {
+		owner = append(append(owner, byte(len(label))), label...)
+	}
+	owner = append(owner, 0)
+
+	digest := sha256.Sum256(append(owner, rdata...))
+	return fmt.Sprintf("%s. IN DS %d %d 2 %X", zone, keyTag(rdata), algorithmNumber, digest), nil
+}
+
+// keyTag - computes the key tag of the RDATA of a DNSKEY, see RFC 4034 appendix B
+func keyTag(rdata []byte) uint16 {
+	var accumulator uint32
+	for i, b := range rdata {
+		if i&1 == 1 {
+			accumulator += uint32(b)
+		} else {
+			accumulator += uint32(b) << 8
+		}
+	}
+	accumulator += accumulator >> 16 & 0xFFFF
+	return uint16(accumulator & 0xFFFF)
+}
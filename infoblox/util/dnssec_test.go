@@ -0,0 +1,71 @@
+package util
+
+import (
+	"github.com/sky-uk/skyinfoblox/api/zoneauth"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// The DNSKEY of dskey.example.com of RFC 4509 section 2.2.2, whose SHA-256 DS record is given there
+const rfc4509PublicKey = "AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw=="
+
+func TestBuildDSRecord(t *testing.T) {
+	dsRecord, err := BuildDSRecord("dskey.example.com", 256, "5", rfc4509PublicKey)
+	assert.Nil(t, err)
+	assert.Equal(t, "dskey.example.com. IN DS 60485 5 2 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A", dsRecord)
+
+	dsRecord, err = BuildDSRecord("DSKEY.example.com.", 256, "RSASHA1", rfc4509PublicKey)
+	assert.Nil(t, err)
+	assert.Equal(t, "dskey.example.com. IN DS 60485 5 2 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A", dsRecord)
+
+	_, err = BuildDSRecord("dskey.example.com", 256, "GOST", rfc4509PublicKey)
+	assert.NotNil(t, err)
+}
+
+func TestBuildDSRecords(t *testing.T) {
+	IBXKeys := []zoneauth.DNSSecKey{
+		{Algorithm: "8", PublicKey: rfc4509PublicKey, Type: "ZSK", Status: "ACTIVE"},
+		{Algorithm: "8", PublicKey: rfc4509PublicKey, Type: "KSK", Status: "ACTIVE"},
+	}
+	dsRecords, err := BuildDSRecords("example.com", IBXKeys)
+	assert.Nil(t, err)
+	assert.Len(t, dsRecords, 1)
+	assert.Regexp(t, "^example.com. IN DS [0-9]+ 8 2 [0-9A-F]{64}$", dsRecords[0])
+
+	// only the keys resolvers may validate the zone with have their DS record published
+	for status, published := range map[string]bool{"ACTIVE": true, "PUBLISHED": true, "NEW": false, "WITHDRAWN": false, "": false} {
+		dsRecords, err = BuildDSRecords("example.com", []zoneauth.DNSSecKey{{Algorithm: "8", PublicKey: rfc4509PublicKey, Type: "KSK", Status: status}})
+		assert.Nil(t, err)
+		assert.Equal(t, published, len(dsRecords) == 1, status)
+	}
+}
+
+func TestBuildDNSSecKeyParams(t *testing.T) {
+	dnssecFromT := []interface{}{
+		map[string]interface{}{
+			"ksk_algorithm":            []interface{}{map[string]interface{}{"algorithm": "RSASHA256", "size": 2048}},
+			"ksk_rollover":             31536000,
+			"zsk_algorithm":            []interface{}{map[string]interface{}{"algorithm": "RSASHA256", "size": 1024}},
+			"zsk_rollover":             2592000,
+			"zsk_rollover_mechanism":   "PRE_PUBLISH",
+			"enable_ksk_auto_rollover": true,
+			"next_secure_type":         "NSEC3",
+			"nsec3_iterations":         10,
+			"nsec3_salt_min_length":    1,
+			"nsec3_salt_max_length":    15,
+			"signature_expiration":     345600,
+		},
+	}
+	IBXKeyParams := BuildDNSSecKeyParamsFromT(dnssecFromT)
+	assert.Equal(t, []zoneauth.DNSSecKeyAlgorithm{{Algorithm: "RSASHA256", Size: 2048}}, IBXKeyParams.KskAlgorithms)
+	assert.Equal(t, []zoneauth.ZskAlgorithm{{Algorithm: "RSASHA256", Size: 1024}}, IBXKeyParams.ZskAlgorithms)
+	assert.Equal(t, "NSEC3", IBXKeyParams.NextSecureType)
+
+	dnssec := BuildDNSSecFromIBX(IBXKeyParams)
+	assert.Equal(t, dnssecFromT[0].(map[string]interface{})["ksk_rollover"], dnssec[0]["ksk_rollover"])
+	assert.Equal(t, true, dnssec[0]["enable_ksk_auto_rollover"])
+	assert.Equal(t, []map[string]interface{}{{"algorithm": "RSASHA256", "size": 1024}}, dnssec[0]["zsk_algorithm"])
+
+	assert.Nil(t, BuildDNSSecKeyParamsFromT([]interface{}{}))
+	assert.Equal(t, []map[string]interface{}{}, BuildDNSSecFromIBX(nil))
+}
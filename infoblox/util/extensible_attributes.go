@@ -0,0 +1,228 @@
+package util
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox/api/common"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// extAttrDefFlags - the letters of the WAPI flags of an extensible attribute definition
+const extAttrDefFlags = "ACGILMPRSV"
+
+// ExtAttrsSchema - returns the schema for the extensible attributes of an object
+func ExtAttrsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: "Extensible attributes of the object, keyed by attribute name",
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// InheritedExtAttrsSchema - returns the schema for the extensible attributes an object inherits from its parents
+func InheritedExtAttrsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: "Extensible attributes inherited from the parent objects, keyed by attribute name",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// ExtAttrsDescendantsActionSchema - returns the schema controlling how inheritable extensible attributes
+// set on an object are applied to its descendants
+func ExtAttrsDescendantsActionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "How the inheritable extensible attributes of the object are applied to its descendants",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"option_with_ea": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "Action for descendants which already have the attribute: CONVERT, INHERIT or RETAIN",
+					ValidateFunc: ValidateExtAttrsDescendantsOption("CONVERT", "INHERIT", "RETAIN"),
+				},
+				"option_without_ea": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "Action for descendants without the attribute: INHERIT or NOT_INHERIT",
+					ValidateFunc: ValidateExtAttrsDescendantsOption("INHERIT", "NOT_INHERIT"),
+				},
+				"option_delete_ea": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "Action for descendants when the attribute is removed: REMOVE or RETAIN",
+					ValidateFunc: ValidateExtAttrsDescendantsOption("REMOVE", "RETAIN"),
+				},
+			},
+		},
+	}
+}
+
+// ValidateExtAttrsDescendantsOption - returns a validation function accepting one of the given descendants actions
+func ValidateExtAttrsDescendantsOption(options ...string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		for _, option := range options {
+			if value == option {
+				return
+			}
+		}
+		errors = append(errors, fmt.Errorf("%q must be one of %v", k, options))
+		return
+	}
+}
+
+// ValidateExtAttrType - Checks the value is a valid extensible attribute definition type
+func ValidateExtAttrType(v interface{}, k string) (ws []string, errors []error) {
+	switch v.(string) {
+	case "STRING", "INTEGER", "ENUM", "EMAIL", "URL", "DATE":
+	default:
+		errors = append(errors, fmt.Errorf("%q must be one of STRING, INTEGER, ENUM, EMAIL, URL or DATE", k))
+	}
+	return
+}
+
+// ValidateExtAttrFlags - Checks the value only holds extensible attribute definition flags, each at most once
+func ValidateExtAttrFlags(v interface{}, k string) (ws []string, errors []error) {
+	flags := v.(string)
+	for i, flag := range flags {
+		if !strings.ContainsRune(extAttrDefFlags, flag) || strings.ContainsRune(flags[i+1:], flag) {
+			errors = append(errors, fmt.Errorf("%q must be made of the flags %s, each set at most once", k, extAttrDefFlags))
+			return
+		}
+	}
+	return
+}
+
+// SuppressEquivalentExtAttrFlags - Suppresses the diff between two orderings of the same flags, e.g. IM and MI
+func SuppressEquivalentExtAttrFlags(k, old, new string, d *schema.ResourceData) bool {
+	return sortFlags(old) == sortFlags(new)
+}
+
+func sortFlags(flags string) string {
+	letters := strings.Split(flags, "")
+	sort.Strings(letters)
+	return strings.Join(letters, "")
+}
+
+// BuildExtAttrsFromT - builds the extensible attributes of an object given the corresponding map from state.
+// Nil is returned for a nil map so the attributes are left out, while an empty map is kept so removing every
+// attribute is sent to the grid.
+func BuildExtAttrsFromT(extAttrsFromT map[string]interface{}) *common.ExtensibleAttributes {
+	if extAttrsFromT == nil {
+		return nil
+	}
+	extAttrs := make(common.ExtensibleAttributes)
+	for name, value := range extAttrsFromT {
+		extAttrs[name] = common.ExtensibleAttributeValue{Value: value}
+	}
+	return &extAttrs
+}
+
+// ConvertExtAttrValues - converts the values of extensible attributes, strings in state, to the type of their
+// definition given the definition types keyed by attribute name. NIOS rejects INTEGER values sent as strings.
+// Values which don't parse are left as they are for the grid to report.
+func ConvertExtAttrValues(extAttrs *common.ExtensibleAttributes, extAttrTypes map[string]string) {
+	for name, extAttr := range *extAttrs {
+		value, ok := extAttr.Value.(string)
+		if !ok || extAttrTypes[name] != "INTEGER" {
+			continue
+		}
+		if number, err := strconv.ParseInt(value, 10, 64); err == nil {
+			extAttr.Value = number
+			(*extAttrs)[name] = extAttr
+		}
+	}
+}
+
+// BuildInheritableExtAttrsFromT - builds the extensible attributes of an object given the corresponding map from
+// state, applying the descendants action from state to every attribute
+func BuildInheritableExtAttrsFromT(extAttrsFromT map[string]interface{}, descendantsActionFromT []interface{}) *common.ExtensibleAttributes {
+	extAttrs := BuildExtAttrsFromT(extAttrsFromT)
+	if extAttrs == nil || len(descendantsActionFromT) == 0 || descendantsActionFromT[0] == nil {
+		return extAttrs
+	}
+	action := descendantsActionFromT[0].(map[string]interface{})
+	descendantsAction := &common.ExtensibleAttributeDescendantsAction{
+		OptionWithEA:    action["option_with_ea"].(string),
+		OptionWithoutEA: action["option_without_ea"].(string),
+		OptionDeleteEA:  action["option_delete_ea"].(string),
+	}
+	for name, extAttr := range *extAttrs {
+		extAttr.DescendantsAction = descendantsAction
+		(*extAttrs)[name] = extAttr
+	}
+	return extAttrs
+}
+
+// BuildExtAttrsFromIBX - builds the extensible attributes map for terraform given the corresponding struct from IBX.
+// Values inherited from a parent object are left out, see BuildInheritedExtAttrsFromIBX.
+func BuildExtAttrsFromIBX(IBXExtAttrs *common.ExtensibleAttributes) map[string]interface{} {
+	return buildExtAttrsFromIBX(IBXExtAttrs, false)
+}
+
+// BuildInheritedExtAttrsFromIBX - builds the map of the extensible attributes inherited from a parent object
+func BuildInheritedExtAttrsFromIBX(IBXExtAttrs *common.ExtensibleAttributes) map[string]interface{} {
+	return buildExtAttrsFromIBX(IBXExtAttrs, true)
+}
+
+func buildExtAttrsFromIBX(IBXExtAttrs *common.ExtensibleAttributes, inherited bool) map[string]interface{} {
+	extAttrs := make(map[string]interface{})
+	if IBXExtAttrs == nil {
+		return extAttrs
+	}
+	for name, extAttr := range *IBXExtAttrs {
+		if (extAttr.InheritanceSource != nil) != inherited {
+			continue
+		}
+		extAttrs[name] = extAttrValueToString(extAttr.Value)
+	}
+	return extAttrs
+}
+
+// extAttrValueToString - converts an attribute value decoded from JSON to its string form in state,
+// integers come back as float64 and must not be printed in exponent form
+func extAttrValueToString(value interface{}) string {
+	if number, ok := value.(float64); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// MergeExtAttrs - merges the default extensible attributes with the ones set on a resource,
+// the resource values win when an attribute is set in both. Nil is returned when neither sets an attribute.
+func MergeExtAttrs(defaultExtAttrs, extAttrs map[string]interface{}) map[string]interface{} {
+	if len(defaultExtAttrs) == 0 && len(extAttrs) == 0 {
+		return nil
+	}
+	merged := make(map[string]interface{})
+	for name, value := range defaultExtAttrs {
+		merged[name] = value
+	}
+	for name, value := range extAttrs {
+		merged[name] = value
+	}
+	return merged
+}
+
+// RemoveDefaultExtAttrs - removes the default extensible attributes from the attributes read from the grid so they
+// don't show up as drift. Attributes also set on the resource, or whose value no longer matches the default, are kept.
+func RemoveDefaultExtAttrs(extAttrs, defaultExtAttrs, resourceExtAttrs map[string]interface{}) map[string]interface{} {
+	filtered := make(map[string]interface{})
+	for name, value := range extAttrs {
+		if _, ok := resourceExtAttrs[name]; !ok {
+			if defaultValue, ok := defaultExtAttrs[name]; ok && defaultValue == value {
+				continue
+			}
+		}
+		filtered[name] = value
+	}
+	return filtered
+}
@@ -0,0 +1,183 @@
+package util
+
+import (
+	"github.com/sky-uk/skyinfoblox/api/common"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuildExtAttrsFromT(t *testing.T) {
+	extAttrsFromT := map[string]interface{}{
+		"Site":  "London",
+		"Owner": "network team",
+	}
+
+	IBXExtAttrs := &common.ExtensibleAttributes{
+		"Site":  {Value: "London"},
+		"Owner": {Value: "network team"},
+	}
+
+	assert.Equal(t, IBXExtAttrs, BuildExtAttrsFromT(extAttrsFromT))
+	assert.Equal(t, &common.ExtensibleAttributes{}, BuildExtAttrsFromT(map[string]interface{}{}))
+	assert.Nil(t, BuildExtAttrsFromT(nil))
+}
+
+func TestConvertExtAttrValues(t *testing.T) {
+	extAttrs := &common.ExtensibleAttributes{
+		"Site":     {Value: "London"},
+		"VLanID":   {Value: "120"},
+		"CostCode": {Value: "not a number"},
+		"Tenant":   {Value: "42"},
+	}
+	extAttrTypes := map[string]string{
+		"Site":     "STRING",
+		"VLanID":   "INTEGER",
+		"CostCode": "INTEGER",
+	}
+
+	ConvertExtAttrValues(extAttrs, extAttrTypes)
+	assert.Equal(t, &common.ExtensibleAttributes{
+		"Site":     {Value: "London"},
+		"VLanID":   {Value: int64(120)},
+		"CostCode": {Value: "not a number"},
+		"Tenant":   {Value: "42"},
+	}, extAttrs)
+
+	ConvertExtAttrValues(extAttrs, nil)
+	assert.Equal(t, int64(120), (*extAttrs)["VLanID"].Value)
+}
+
+func TestBuildInheritableExtAttrsFromT(t *testing.T) {
+	extAttrsFromT := map[string]interface{}{
+		"Site": "London",
+	}
+	descendantsActionFromT := []interface{}{
+		map[string]interface{}{
+			"option_with_ea":    "INHERIT",
+			"option_without_ea": "INHERIT",
+			"option_delete_ea":  "",
+		},
+	}
+
+	IBXExtAttrs := &common.ExtensibleAttributes{
+		"Site": {
+			Value: "London",
+			DescendantsAction: &common.ExtensibleAttributeDescendantsAction{
+				OptionWithEA:    "INHERIT",
+				OptionWithoutEA: "INHERIT",
+			},
+		},
+	}
+
+	assert.Equal(t, IBXExtAttrs, BuildInheritableExtAttrsFromT(extAttrsFromT, descendantsActionFromT))
+	assert.Equal(t, BuildExtAttrsFromT(extAttrsFromT), BuildInheritableExtAttrsFromT(extAttrsFromT, nil))
+	assert.Nil(t, BuildInheritableExtAttrsFromT(nil, descendantsActionFromT))
+}
+
+func TestBuildExtAttrsFromIBX(t *testing.T) {
+	IBXExtAttrs := &common.ExtensibleAttributes{
+		"Site":     {Value: "London"},
+		"VLanID":   {Value: float64(120)},
+		"CostCode": {Value: float64(10000000)},
+		"Tenant": {
+			Value:             "platform",
+			InheritanceSource: &common.ExtensibleAttributeInheritanceSource{Ref: "networkcontainer/ZG5zLm5ldHdvcmtfY29udGFpbmVy:10.0.0.0/8/default"},
+		},
+	}
+
+	extAttrs := map[string]interface{}{
+		"Site":     "London",
+		"VLanID":   "120",
+		"CostCode": "10000000",
+	}
+	inheritedExtAttrs := map[string]interface{}{
+		"Tenant": "platform",
+	}
+
+	assert.Equal(t, extAttrs, BuildExtAttrsFromIBX(IBXExtAttrs))
+	assert.Equal(t, inheritedExtAttrs, BuildInheritedExtAttrsFromIBX(IBXExtAttrs))
+	assert.Equal(t, map[string]interface{}{}, BuildExtAttrsFromIBX(nil))
+}
+
+func TestValidateExtAttrsDescendantsOption(t *testing.T) {
+	validate := ValidateExtAttrsDescendantsOption("INHERIT", "NOT_INHERIT")
+
+	_, errs := validate("INHERIT", "option_without_ea")
+	assert.Empty(t, errs)
+
+	_, errs = validate("RETAIN", "option_without_ea")
+	assert.NotEmpty(t, errs)
+}
+
+func TestMergeExtAttrs(t *testing.T) {
+	defaultExtAttrs := map[string]interface{}{
+		"ManagedBy": "terraform",
+		"Team":      "network team",
+	}
+	extAttrs := map[string]interface{}{
+		"Team": "platform team",
+		"Site": "London",
+	}
+
+	merged := map[string]interface{}{
+		"ManagedBy": "terraform",
+		"Team":      "platform team",
+		"Site":      "London",
+	}
+
+	assert.Equal(t, merged, MergeExtAttrs(defaultExtAttrs, extAttrs))
+	assert.Equal(t, extAttrs, MergeExtAttrs(nil, extAttrs))
+	assert.Nil(t, MergeExtAttrs(nil, nil))
+	assert.Nil(t, MergeExtAttrs(map[string]interface{}{}, map[string]interface{}{}))
+}
+
+func TestRemoveDefaultExtAttrs(t *testing.T) {
+	defaultExtAttrs := map[string]interface{}{
+		"ManagedBy": "terraform",
+		"Team":      "network team",
+		"Tenant":    "shared",
+	}
+	resourceExtAttrs := map[string]interface{}{
+		"Team": "network team",
+		"Site": "London",
+	}
+	extAttrs := map[string]interface{}{
+		"ManagedBy": "terraform",
+		"Team":      "network team",
+		"Tenant":    "changed outside terraform",
+		"Site":      "London",
+	}
+
+	filtered := map[string]interface{}{
+		"Team":   "network team",
+		"Tenant": "changed outside terraform",
+		"Site":   "London",
+	}
+
+	assert.Equal(t, filtered, RemoveDefaultExtAttrs(extAttrs, defaultExtAttrs, resourceExtAttrs))
+}
+
+func TestValidateExtAttrType(t *testing.T) {
+	for _, extAttrType := range []string{"STRING", "INTEGER", "ENUM", "EMAIL", "URL", "DATE"} {
+		_, errs := ValidateExtAttrType(extAttrType, "type")
+		assert.Empty(t, errs, extAttrType)
+	}
+	_, errs := ValidateExtAttrType("string", "type")
+	assert.Len(t, errs, 1)
+}
+
+func TestValidateExtAttrFlags(t *testing.T) {
+	for _, flags := range []string{"", "I", "IM", "ACGILMPRSV"} {
+		_, errs := ValidateExtAttrFlags(flags, "flags")
+		assert.Empty(t, errs, flags)
+	}
+	for _, flags := range []string{"X", "II", "im"} {
+		_, errs := ValidateExtAttrFlags(flags, "flags")
+		assert.Len(t, errs, 1, flags)
+	}
+}
+
+func TestSuppressEquivalentExtAttrFlags(t *testing.T) {
+	assert.True(t, SuppressEquivalentExtAttrFlags("flags", "MI", "IM", nil))
+	assert.False(t, SuppressEquivalentExtAttrFlags("flags", "MI", "I", nil))
+}
@@ -5,8 +5,6 @@ import (
 	"github.com/sky-uk/skyinfoblox/api/common"
 )
 
-// TODO - methods TBD
-
 // ForwardingMemberServerListSchema - returns a list of Forwarding Member Servers
 func ForwardingMemberServerListSchema() *schema.Schema {
 	return &schema.Schema{
@@ -75,41 +73,23 @@ func BuildForwardingMemberServerListFromT(serverListFromT []map[string]interface
 
 // BuildForwardingMemberServerListFromIBX -  builds a list of forwarding member servers for terraform given
 // the corresponding struct from IBX
-func BuildForwardingMemberServerListFromIBX(IBXExtServersList []common.ExternalServer) []map[string]interface{} {
-	es := make([]map[string]interface{}, 0)
-	for _, IBXExtServer := range IBXExtServersList {
+func BuildForwardingMemberServerListFromIBX(IBXServersList []common.ForwardingMemberServer) []map[string]interface{} {
+	servers := make([]map[string]interface{}, 0)
+	for _, IBXServer := range IBXServersList {
 		server := make(map[string]interface{})
+		server["name"] = IBXServer.Name
+		server["forward_to"] = BuildExternalServersListFromIBX(IBXServer.ForwardTo)
 
-		if IBXExtServer.Address != "" {
-			server["address"] = IBXExtServer.Address
-		}
-
-		if IBXExtServer.Name != "" {
-			server["name"] = IBXExtServer.Name
-		}
-
-		if IBXExtServer.Stealth != nil {
-			server["stealth"] = *IBXExtServer.Stealth
-		}
-
-		if IBXExtServer.TsigKey != "" {
-			server["tsigkey"] = IBXExtServer.TsigKey
-		}
-
-		if IBXExtServer.TsigKeyAlg != "" {
-			server["tsigkeyalg"] = IBXExtServer.TsigKeyAlg
-		}
-
-		if IBXExtServer.TsigKeyName != "" {
-			server["tsigkeyname"] = IBXExtServer.TsigKeyName
+		if IBXServer.ForwardersOnly != nil {
+			server["forwarders_only"] = *IBXServer.ForwardersOnly
 		}
 
-		if IBXExtServer.UseTsigKeyName != nil {
-			server["usetsigkeyname"] = *IBXExtServer.UseTsigKeyName
+		if IBXServer.UseOverrideForwarders != nil {
+			server["use_override_forwarders"] = *IBXServer.UseOverrideForwarders
 		}
 
-		es = append(es, server)
+		servers = append(servers, server)
 	}
 
-	return es
+	return servers
 }
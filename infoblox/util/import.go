@@ -0,0 +1,37 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IsReference - Checks whether an ID is a WAPI reference to an object of the given type,
+// e.g. zone_auth/ZG5zLnpvbmUkLl9kZWZhdWx0LmNvbS5leGFtcGxl:example.com/default
+func IsReference(objectType, id string) bool {
+	return strings.HasPrefix(id, objectType+"/")
+}
+
+// ParseImportKey - Splits a friendly import key into the values of the given fields.
+// Values are separated by "/". As CIDRs and reverse zones contain a "/" themselves any surplus
+// separators are kept in the first value, so 10.0.0.0/24/default gives 10.0.0.0/24 and default.
+func ParseImportKey(key string, fields []string) (map[string]string, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields to parse import key %q into", key)
+	}
+	parts := strings.Split(key, "/")
+	if len(parts) < len(fields) {
+		return nil, fmt.Errorf("import key %q must be in the format %s", key, strings.Join(fields, "/"))
+	}
+	surplus := len(parts) - len(fields)
+	values := make(map[string]string)
+	values[fields[0]] = strings.Join(parts[:surplus+1], "/")
+	for idx, field := range fields[1:] {
+		values[field] = parts[surplus+1+idx]
+	}
+	for _, field := range fields {
+		if values[field] == "" {
+			return nil, fmt.Errorf("import key %q has an empty value for %s", key, field)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,42 @@
+package util
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsReference(t *testing.T) {
+	assert.True(t, IsReference("zone_auth", "zone_auth/ZG5zLnpvbmUkLl9kZWZhdWx0LmNvbS5leGFtcGxl:example.com/default"))
+	assert.True(t, IsReference("record:a", "record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQ:foo.example.com/default"))
+	assert.False(t, IsReference("zone_auth", "example.com/default"))
+	assert.False(t, IsReference("record:a", "record:aaaa/ZG5zLmJpbmRfYWFhYSQ:foo.example.com/default"))
+}
+
+func TestParseImportKey(t *testing.T) {
+	values, err := ParseImportKey("example.com/default", []string{"fqdn", "view"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"fqdn": "example.com", "view": "default"}, values)
+
+	values, err = ParseImportKey("10.0.0.0/24/default", []string{"network", "network_view"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"network": "10.0.0.0/24", "network_view": "default"}, values)
+
+	values, err = ParseImportKey("admin", []string{"name"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"name": "admin"}, values)
+
+	values, err = ParseImportKey("10.0.0.10/10.0.0.20/default", []string{"start_addr", "end_addr", "network_view"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"start_addr": "10.0.0.10", "end_addr": "10.0.0.20", "network_view": "default"}, values)
+}
+
+func TestParseImportKeyErrors(t *testing.T) {
+	_, err := ParseImportKey("example.com", []string{"fqdn", "view"})
+	assert.EqualError(t, err, `import key "example.com" must be in the format fqdn/view`)
+
+	_, err = ParseImportKey("example.com/", []string{"fqdn", "view"})
+	assert.EqualError(t, err, `import key "example.com/" has an empty value for view`)
+
+	_, err = ParseImportKey("example.com", []string{})
+	assert.NotNil(t, err)
+}
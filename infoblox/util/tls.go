@@ -0,0 +1,69 @@
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+)
+
+// ReadPEM - returns the PEM content of a setting holding either PEM or the path of a PEM file
+func ReadPEM(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+	content, err := ioutil.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the PEM file %s: %s", value, err)
+	}
+	return content, nil
+}
+
+// BuildTLSConfig - builds the TLS settings of the client. The CA bundle, client certificate and client key
+// are each either PEM or the path of a PEM file, empty ones are left out.
+func BuildTLSConfig(caBundle, clientCertificate, clientKey, serverName string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{ServerName: serverName}
+
+	if caBundle != "" {
+		caPEM, err := ReadPEM(caBundle)
+		if err != nil {
+			return nil, err
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("The CA bundle doesn't hold any PEM encoded certificate")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if (clientCertificate == "") != (clientKey == "") {
+		return nil, fmt.Errorf("client_certificate and client_key must be set together")
+	}
+	if clientCertificate != "" {
+		certificatePEM, err := ReadPEM(clientCertificate)
+		if err != nil {
+			return nil, err
+		}
+		keyPEM, err := ReadPEM(clientKey)
+		if err != nil {
+			return nil, err
+		}
+		certificate, err := tls.X509KeyPair(certificatePEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("Could not load the client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
+
+// ValidateProxyURL - Checks the value is an http or https proxy URL
+func ValidateProxyURL(v interface{}, k string) (ws []string, errors []error) {
+	proxyURL, err := url.Parse(v.(string))
+	if err != nil || (proxyURL.Scheme != "http" && proxyURL.Scheme != "https") || proxyURL.Host == "" {
+		errors = append(errors, fmt.Errorf("%q must be an http:// or https:// URL", k))
+	}
+	return
+}
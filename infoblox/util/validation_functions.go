@@ -2,6 +2,8 @@ package util
 
 import (
 	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"net"
 	"strings"
 )
 
@@ -41,3 +43,35 @@ func CheckLeadingTrailingSpaces(v interface{}, k string) (ws []string, errors []
 	}
 	return
 }
+
+// ValidateAddressRange - Checks the value is an IPv4 address range in start_address-end_address format
+func ValidateAddressRange(v interface{}, k string) (ws []string, errors []error) {
+	addresses := strings.SplitN(v.(string), "-", 2)
+	if len(addresses) != 2 || net.ParseIP(addresses[0]).To4() == nil || net.ParseIP(addresses[1]).To4() == nil {
+		errors = append(errors, fmt.Errorf("%q must be in the format start_address-end_address", k))
+	}
+	return
+}
+
+// ValidateIPAddress - Checks the value is an IPv4 or IPv6 address
+func ValidateIPAddress(v interface{}, k string) (ws []string, errors []error) {
+	if net.ParseIP(v.(string)) == nil {
+		errors = append(errors, fmt.Errorf("%q must be an IPv4 or IPv6 address", k))
+	}
+	return
+}
+
+// ValidateIPv6Address - Checks the value is an IPv6 address
+func ValidateIPv6Address(v interface{}, k string) (ws []string, errors []error) {
+	address := net.ParseIP(v.(string))
+	if address == nil || address.To4() != nil {
+		errors = append(errors, fmt.Errorf("%q must be an IPv6 address", k))
+	}
+	return
+}
+
+// SuppressEquivalentIPAddresses - Suppresses the diff between two spellings of the same IP address, e.g. 2001:db8::1 and 2001:0db8:0:0::1
+func SuppressEquivalentIPAddresses(k, old, new string, d *schema.ResourceData) bool {
+	oldAddress := net.ParseIP(old)
+	return oldAddress != nil && oldAddress.Equal(net.ParseIP(new))
+}
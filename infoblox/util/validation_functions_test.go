@@ -0,0 +1,41 @@
+package util
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateAddressRange(t *testing.T) {
+	_, errors := ValidateAddressRange("10.0.0.10-10.0.0.20", "range")
+	assert.Empty(t, errors)
+
+	for _, addressRange := range []string{"10.0.0.10", "10.0.0.10-", "10.0.0.10-foo", "10.0.0.0/24"} {
+		_, errors = ValidateAddressRange(addressRange, "range")
+		assert.Len(t, errors, 1, addressRange)
+	}
+}
+
+func TestValidateIPAddress(t *testing.T) {
+	for _, address := range []string{"10.0.0.1", "2001:db8::1"} {
+		_, errors := ValidateIPAddress(address, "address")
+		assert.Empty(t, errors, address)
+	}
+	_, errors := ValidateIPAddress("1.0.0.10.in-addr.arpa", "address")
+	assert.Len(t, errors, 1)
+}
+
+func TestValidateIPv6Address(t *testing.T) {
+	_, errors := ValidateIPv6Address("2001:db8::1", "address")
+	assert.Empty(t, errors)
+
+	for _, address := range []string{"10.0.0.1", "foo"} {
+		_, errors = ValidateIPv6Address(address, "address")
+		assert.Len(t, errors, 1, address)
+	}
+}
+
+func TestSuppressEquivalentIPAddresses(t *testing.T) {
+	assert.True(t, SuppressEquivalentIPAddresses("address", "2001:db8::1", "2001:0db8:0:0::1", nil))
+	assert.False(t, SuppressEquivalentIPAddresses("address", "2001:db8::1", "2001:db8::2", nil))
+	assert.False(t, SuppressEquivalentIPAddresses("address", "", "2001:db8::1", nil))
+}
@@ -0,0 +1,41 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var wapiVersionFormat = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*$`)
+
+// ValidateWapiVersion - Checks the value is a WAPI version made of dot separated numbers, e.g. 2.6.1
+func ValidateWapiVersion(v interface{}, k string) (ws []string, errors []error) {
+	if !wapiVersionFormat.MatchString(v.(string)) {
+		errors = append(errors, fmt.Errorf("%q must be a WAPI version such as 2.6.1, without the leading v", k))
+	}
+	return
+}
+
+// CompareWapiVersions - compares two WAPI versions number by number, 2.10 being newer than 2.9 and 2.6.1 than 2.6.
+// Returns -1, 0 or 1 when a is older, the same or newer than b.
+func CompareWapiVersions(a, b string) int {
+	aNumbers := strings.Split(a, ".")
+	bNumbers := strings.Split(b, ".")
+	for i := 0; i < len(aNumbers) || i < len(bNumbers); i++ {
+		aNumber, bNumber := 0, 0
+		if i < len(aNumbers) {
+			aNumber, _ = strconv.Atoi(aNumbers[i])
+		}
+		if i < len(bNumbers) {
+			bNumber, _ = strconv.Atoi(bNumbers[i])
+		}
+		if aNumber < bNumber {
+			return -1
+		}
+		if aNumber > bNumber {
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateWapiVersion(t *testing.T) {
+	for _, version := range []string{"2.6.1", "2.7", "1.0"} {
+		_, errors := ValidateWapiVersion(version, "wapi_version")
+		assert.Empty(t, errors, version)
+	}
+	for _, version := range []string{"v2.6.1", "2.6.", "latest", ""} {
+		_, errors := ValidateWapiVersion(version, "wapi_version")
+		assert.Len(t, errors, 1, version)
+	}
+}
+
+func TestCompareWapiVersions(t *testing.T) {
+	assert.Equal(t, 0, CompareWapiVersions("2.6.1", "2.6.1"))
+	assert.Equal(t, 0, CompareWapiVersions("2.7", "2.7.0"))
+	assert.Equal(t, 1, CompareWapiVersions("2.10", "2.9"))
+	assert.Equal(t, 1, CompareWapiVersions("2.6.1", "2.6"))
+	assert.Equal(t, -1, CompareWapiVersions("1.7.5", "2.0"))
+}
@@ -0,0 +1,247 @@
+package infoblox
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/skyinfoblox"
+	"github.com/sky-uk/skyinfoblox/api"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// wapiObjectTypes - the WAPI object type managed by each resource and data source. Resources of object types only
+// newer NIOS releases have, e.g. namedacl, need no entry in wapiGatedFields: they fail as a whole when the grid
+// doesn't list their type among the objects of the WAPI version.
+var wapiObjectTypes = map[string]string{
+	"infoblox_arecord":                         "record:a",
+	"infoblox_aaaa_record":                     "record:aaaa",
+	"infoblox_cname_record":                    "record:cname",
+	"infoblox_host_record":                     "record:host",
+	"infoblox_mx_record":                       "record:mx",
+	"infoblox_ns_record":                       "record:ns",
+	"infoblox_ptr_record":                      "record:ptr",
+	"infoblox_srv_record":                      "record:srv",
+	"infoblox_txtrecord":                       "record:txt",
+	"infoblox_txt_record":                      "record:txt",
+	"infoblox_network":                         "network",
+	"infoblox_network_container":               "networkcontainer",
+	"infoblox_dhcp_range":                      "range",
+	"infoblox_zone_auth":                       "zone_auth",
+	"infoblox_zone_delegated":                  "zone_delegated",
+	"infoblox_zone_forward":                    "zone_forward",
+	"infoblox_zone_stub":                       "zone_stub",
+	"infoblox_ns_group_delegation":             "nsgroup:delegation",
+	"infoblox_admin_user":                      "adminuser",
+	"infoblox_admin_group":                     "admingroup",
+	"infoblox_admin_role":                      "adminrole",
+	"infoblox_permission":                      "permission",
+	"infoblox_extensible_attribute_definition": "extensibleattributedef",
+	"infoblox_named_acl":                       "namedacl",
+	"infoblox_dns_view":                        "view",
+	"infoblox_network_view":                    "networkview",
+}
+
+// wapiGatedFields - attributes backed by WAPI fields only newer NIOS releases have, keyed by resource
+// then attribute name. Setting one of them fails when the WAPI version of the provider lacks the field.
+var wapiGatedFields = map[string]map[string]string{
+	"infoblox_network": {
+		"enablediscovery":     "enable_discovery",
+		"use_enablediscovery": "use_enable_discovery",
+		"discovery_member":    "discovery_member",
+	},
+	"infoblox_network_container": {
+		"enablediscovery":     "enable_discovery",
+		"use_enablediscovery": "use_enable_discovery",
+		"discovery_member":    "discovery_member",
+	},
+	"infoblox_extensible_attribute_definition": {
+		"allowed_object_types": "allowed_object_types",
+	},
+	"infoblox_zone_auth": {
+		"dnssec":                      "dnssec_key_params",
+		"update_forwarding":           "update_forwarding",
+		"allow_update_forwarding":     "allow_update_forwarding",
+		"use_allow_update_forwarding": "use_allow_update_forwarding",
+	},
+}
+
+// wapiCapabilities - what the WAPI version of a configured provider supports
+type wapiCapabilities struct {
+	sync.Mutex
+	version          string
+	supportedObjects map[string]bool
+	objectFields     map[string]map[string]bool
+}
+
+// detectWapiCapabilities - checks the grid supports the WAPI version of the client and returns the objects it offers,
+// failing early with the versions the grid supports otherwise
+func detectWapiCapabilities(infobloxClient *skyinfoblox.InfobloxClient) (*wapiCapabilities, error) {
+	versionsAPI := api.NewGetSupportedVersions()
+	err := infobloxClient.Do(versionsAPI)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the WAPI versions supported by %s: %+v", infobloxClient.URL, err)
+	}
+	if skyinfoblox.IsAuth(versionsAPI.Error()) {
+		return nil, fmt.Errorf("%s rejected the credentials of %s, check username and password: %s", infobloxClient.URL, infobloxClient.User, versionsAPI.Error())
+	}
+	if versionsAPI.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("Infoblox Schema Error: Invalid HTTP response code %d returned - response %s", versionsAPI.StatusCode(), string(versionsAPI.RawResponse()))
+	}
+	supportedVersions := versionsAPI.ResponseObject().(*api.Schema).SupportedVersions
+	if !wapiVersionSupported(infobloxClient.WapiVersion, supportedVersions) {
+		return nil, fmt.Errorf("WAPI version %s is not supported by %s, set wapi_version to one of %s",
+			infobloxClient.WapiVersion, infobloxClient.URL, strings.Join(supportedVersions, ", "))
+	}
+
+	schemaAPI := api.NewGetSchema()
+	err = infobloxClient.Do(schemaAPI)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the WAPI %s schema of %s: %+v", infobloxClient.WapiVersion, infobloxClient.URL, err)
+	}
+	if schemaAPI.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("Infoblox Schema Error: Invalid HTTP response code %d returned - response %s", schemaAPI.StatusCode(), string(schemaAPI.RawResponse()))
+	}
+	capabilities := &wapiCapabilities{
+		version:          infobloxClient.WapiVersion,
+		supportedObjects: make(map[string]bool),
+		objectFields:     make(map[string]map[string]bool),
+	}
+	for _, objectType := range schemaAPI.ResponseObject().(*api.Schema).SupportedObjects {
+		capabilities.supportedObjects[objectType] = true
+	}
+	return capabilities, nil
+}
+
+func wapiVersionSupported(version string, supportedVersions []string) bool {
+	for _, supportedVersion := range supportedVersions {
+		if util.CompareWapiVersions(version, supportedVersion) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// capabilitiesOf - returns the WAPI capabilities of the provider, nil when they weren't detected
+func capabilitiesOf(m interface{}) *wapiCapabilities {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return nil
+	}
+	return meta.capabilities
+}
+
+// fields - returns the fields of an object type in the WAPI version of the provider, fetching its schema on first use
+func (capabilities *wapiCapabilities) fields(infobloxClient *skyinfoblox.InfobloxClient, objectType string) (map[string]bool, error) {
+	capabilities.Lock()
+	defer capabilities.Unlock()
+	if fields, ok := capabilities.objectFields[objectType]; ok {
+		return fields, nil
+	}
+	schemaAPI := api.NewGetObjectSchema(objectType)
+	err := infobloxClient.Do(schemaAPI)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the WAPI %s schema of %s: %+v", capabilities.version, objectType, err)
+	}
+	if schemaAPI.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("Infoblox Schema Error: Invalid HTTP response code %d returned - response %s", schemaAPI.StatusCode(), string(schemaAPI.RawResponse()))
+	}
+	fields := make(map[string]bool)
+	for _, field := range schemaAPI.ResponseObject().(*api.ObjectSchema).Fields {
+		fields[field.Name] = true
+	}
+	capabilities.objectFields[objectType] = fields
+	return fields, nil
+}
+
+// supportedFields - filters the return fields of an object type down to the ones the WAPI version of the provider has,
+// so reading an object doesn't fail on a grid lacking a gated field
+func supportedFields(m interface{}, objectType string, returnFields []string) ([]string, error) {
+	capabilities := capabilitiesOf(m)
+	if capabilities == nil {
+		return returnFields, nil
+	}
+	fields, err := capabilities.fields(m.(*providerMeta).client, objectType)
+	if err != nil {
+		return nil, err
+	}
+	filtered := make([]string, 0, len(returnFields))
+	for _, field := range returnFields {
+		if fields[field] {
+			filtered = append(filtered, field)
+		}
+	}
+	return filtered, nil
+}
+
+// checkWapiObject - fails when the WAPI version of the provider doesn't offer the object type of a resource
+func checkWapiObject(m interface{}, name string) error {
+	capabilities := capabilitiesOf(m)
+	objectType, ok := wapiObjectTypes[name]
+	if capabilities == nil || !ok || capabilities.supportedObjects[objectType] {
+		return nil
+	}
+	return fmt.Errorf("%s needs the WAPI object %s, which WAPI version %s doesn't support. Set wapi_version to a newer version supported by the grid",
+		name, objectType, capabilities.version)
+}
+
+// checkWapiFields - fails when an attribute set on a resource needs a WAPI field the version of the provider doesn't have
+func checkWapiFields(d *schema.ResourceData, m interface{}, name string) error {
+	capabilities := capabilitiesOf(m)
+	if capabilities == nil {
+		return nil
+	}
+	for attribute, field := range wapiGatedFields[name] {
+		if _, ok := d.GetOk(attribute); !ok {
+			continue
+		}
+		fields, err := capabilities.fields(m.(*providerMeta).client, wapiObjectTypes[name])
+		if err != nil {
+			return err
+		}
+		if !fields[field] {
+			return fmt.Errorf("%s of %s needs the WAPI field %s, which WAPI version %s doesn't support. Set wapi_version to a newer version supported by the grid",
+				attribute, name, field, capabilities.version)
+		}
+	}
+	return nil
+}
+
+// gateOnWapiVersion - wraps the functions of the resources and data sources so they fail with a clear message
+// when the WAPI version of the provider lacks their object type, or the field behind a gated attribute
+func gateOnWapiVersion(resources map[string]*schema.Resource) {
+	for name, resource := range resources {
+		resource.Create = gateCreateOrUpdate(name, resource.Create)
+		resource.Update = gateCreateOrUpdate(name, resource.Update)
+		resource.Read = gateReadOrDelete(name, resource.Read)
+		resource.Delete = gateReadOrDelete(name, resource.Delete)
+	}
+}
+
+func gateCreateOrUpdate(name string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, m interface{}) error {
+		if err := checkWapiObject(m, name); err != nil {
+			return err
+		}
+		if err := checkWapiFields(d, m, name); err != nil {
+			return err
+		}
+		return f(d, m)
+	}
+}
+
+func gateReadOrDelete(name string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, m interface{}) error {
+		if err := checkWapiObject(m, name); err != nil {
+			return err
+		}
+		return f(d, m)
+	}
+}
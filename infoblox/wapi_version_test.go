@@ -0,0 +1,98 @@
+package infoblox
+
+import (
+	"context"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/wapitest"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestDetectWapiCapabilitiesUnsupportedVersion(t *testing.T) {
+	server := wapitest.NewServer()
+	defer server.Close()
+
+	_, err := configureClient(testProviderResourceData(t, server.URL, map[string]interface{}{"wapi_version": "2.4"}), context.Background())
+	if err == nil || !regexp.MustCompile(`WAPI version 2.4 is not supported by .*, set wapi_version to one of .*2\.6\.1`).MatchString(err.Error()) {
+		t.Fatalf("Expected the provider to list the supported WAPI versions, got %v", err)
+	}
+}
+
+func TestDetectWapiCapabilitiesBadCredentials(t *testing.T) {
+	server := wapitest.NewServer()
+	defer server.Close()
+
+	_, err := configureClient(testProviderResourceData(t, server.URL, map[string]interface{}{"password": "wrong"}), context.Background())
+	if err == nil || !regexp.MustCompile(`rejected the credentials of admin, check username and password: Infoblox WAPI error 401`).MatchString(err.Error()) {
+		t.Fatalf("Expected the provider configuration to fail on the credentials, got %v", err)
+	}
+}
+
+func TestDetectWapiCapabilitiesSchemaError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "maintenance in progress", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := configureClient(testProviderResourceData(t, server.URL, nil), context.Background())
+	if err == nil || !regexp.MustCompile(`Invalid HTTP response code 500`).MatchString(err.Error()) {
+		t.Fatalf("Expected the provider configuration to fail on the _schema error, got %v", err)
+	}
+
+	server.Close()
+	_, err = configureClient(testProviderResourceData(t, server.URL, nil), context.Background())
+	if err == nil || !regexp.MustCompile(`Could not get the WAPI versions supported by`).MatchString(err.Error()) {
+		t.Fatalf("Expected the provider configuration to fail when the grid can't be reached, got %v", err)
+	}
+}
+
+func TestGateOnWapiVersion(t *testing.T) {
+	server := wapitest.NewServer()
+	defer server.Close()
+
+	provider := Provider().(*schema.Provider)
+	infobloxClient, err := configureClient(testProviderResourceData(t, server.URL, map[string]interface{}{"wapi_version": "2.0"}), context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		resource string
+		raw      map[string]interface{}
+		err      string
+	}{
+		{"infoblox_named_acl", map[string]interface{}{"name": "gated-acl"},
+			`infoblox_named_acl needs the WAPI object namedacl, which WAPI version 2.0 doesn't support`},
+		{"infoblox_network", map[string]interface{}{"network": "10.201.0.0/24", "enablediscovery": true},
+			`enablediscovery of infoblox_network needs the WAPI field enable_discovery, which WAPI version 2.0 doesn't support`},
+		{"infoblox_extensible_attribute_definition", map[string]interface{}{"name": "gated-ea", "type": "STRING", "allowed_object_types": []interface{}{"Network"}},
+			`allowed_object_types of infoblox_extensible_attribute_definition needs the WAPI field allowed_object_types`},
+		{"infoblox_zone_auth", map[string]interface{}{"fqdn": "gated.example.com", "dnssec": []interface{}{map[string]interface{}{"next_secure_type": "NSEC"}}},
+			`dnssec of infoblox_zone_auth needs the WAPI field dnssec_key_params`},
+		{"infoblox_zone_auth", map[string]interface{}{"fqdn": "gated.example.com", "update_forwarding": []interface{}{
+			map[string]interface{}{"type": "addressac", "address": "10.0.0.1", "permission": "ALLOW"}}},
+			`update_forwarding of infoblox_zone_auth needs the WAPI field update_forwarding`},
+		// attributes of fields every version has are left alone, and the newer fields aren't read back
+		{"infoblox_zone_auth", map[string]interface{}{"fqdn": "gated.example.com", "comment": "an old grid"}, ""},
+		{"infoblox_network", map[string]interface{}{"network": "10.201.0.0/24", "comment": "an old grid"}, ""},
+	}
+	for _, test := range tests {
+		resource := provider.ResourcesMap[test.resource]
+		d := schema.TestResourceDataRaw(t, resource.Schema, test.raw)
+		err := resource.Create(d, infobloxClient)
+		if test.err == "" {
+			if err != nil {
+				t.Fatalf("%s: expected the create to succeed on WAPI 2.0, got %s", test.resource, err)
+			}
+			if d.Get("comment") != "an old grid" {
+				t.Fatalf("%s: expected the comment to be read back, got %v", test.resource, d.Get("comment"))
+			}
+			continue
+		}
+		if err == nil || !regexp.MustCompile(test.err).MatchString(err.Error()) {
+			t.Fatalf("%s: expected an error matching %q, got %v", test.resource, test.err, err)
+		}
+	}
+}
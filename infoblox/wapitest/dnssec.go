@@ -0,0 +1,114 @@
+package wapitest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"time"
+)
+
+// dnssecAlgorithmNumbers - the numbers NIOS reports the algorithms of the keys of a signed zone with
+var dnssecAlgorithmNumbers = map[string]string{
+	"RSAMD5": "1", "DSA": "3", "RSASHA1": "5", "NSEC3DSA": "6", "NSEC3RSASHA1": "7", "RSASHA256": "8",
+	"RSASHA512": "10", "ECDSAP256SHA256": "13", "ECDSAP384SHA384": "14",
+}
+
+// defaultDNSSecKeyParams - the grid DNSSEC key parameters of a new zone
+func defaultDNSSecKeyParams() map[string]interface{} {
+	return map[string]interface{}{
+		"ksk_algorithms":           []interface{}{map[string]interface{}{"algorithm": "RSASHA256", "size": 2048}},
+		"ksk_rollover":             31536000,
+		"zsk_algorithms":           []interface{}{map[string]interface{}{"algorithm": "RSASHA256", "size": 1024}},
+		"zsk_rollover":             2592000,
+		"zsk_rollover_mechanism":   "PRE_PUBLISH",
+		"enable_ksk_auto_rollover": false,
+		"next_secure_type":         "NSEC3",
+		"nsec3_iterations":         10,
+		"nsec3_salt_min_length":    1,
+		"nsec3_salt_max_length":    15,
+		"signature_expiration":     345600,
+	}
+}
+
+// dnssecOperation - signs, unsigns, resigns or rolls the keys of an authoritative zone over
+func dnssecOperation(server *Server, obj object, parameters map[string]interface{}) (map[string]interface{}, *wapiError) {
+	signed := obj["is_dnssec_signed"] == true
+	operation := valueString(parameters["operation"])
+	switch operation {
+	case "SIGN":
+		if signed {
+			return nil, errorf(http.StatusBadRequest, codeData, "Zone %s is already signed", valueString(obj["fqdn"]))
+		}
+		obj["dnssec_keys"] = []interface{}{newDNSSecKey(obj, "KSK"), newDNSSecKey(obj, "ZSK")}
+		obj["is_dnssec_signed"], obj["is_dnssec_enabled"] = true, true
+	case "UNSIGN":
+		if !signed {
+			return nil, errorf(http.StatusBadRequest, codeData, "Zone %s is not signed", valueString(obj["fqdn"]))
+		}
+		obj["dnssec_keys"] = []interface{}{}
+		obj["is_dnssec_signed"], obj["is_dnssec_enabled"] = false, false
+		delete(obj, "dnssec_ksk_rollover_date")
+		delete(obj, "dnssec_zsk_rollover_date")
+		return map[string]interface{}{}, nil
+	case "ROLLOVER_KSK", "ROLLOVER_ZSK":
+		if !signed {
+			return nil, errorf(http.StatusBadRequest, codeData, "Zone %s is not signed", valueString(obj["fqdn"]))
+		}
+		keyType := operation[len("ROLLOVER_"):]
+		keys := make([]interface{}, 0)
+		for _, key := range obj["dnssec_keys"].([]interface{}) {
+			if key.(map[string]interface{})["type"] != keyType {
+				keys = append(keys, key)
+			}
+		}
+		obj["dnssec_keys"] = append(keys, newDNSSecKey(obj, keyType))
+	case "RESIGN":
+		if !signed {
+			return nil, errorf(http.StatusBadRequest, codeData, "Zone %s is not signed", valueString(obj["fqdn"]))
+		}
+	default:
+		return nil, errorf(http.StatusBadRequest, codeProto, "Invalid value for operation: %v", parameters["operation"])
+	}
+	keyParams := obj["dnssec_key_params"].(map[string]interface{})
+	now := time.Now().Unix()
+	obj["dnssec_ksk_rollover_date"] = now + toInt64(keyParams["ksk_rollover"])
+	obj["dnssec_zsk_rollover_date"] = now + toInt64(keyParams["zsk_rollover"])
+	return map[string]interface{}{}, nil
+}
+
+// newDNSSecKey - generates a key of a signed zone, with a random public key of the first algorithm configured
+func newDNSSecKey(obj object, keyType string) map[string]interface{} {
+	keyParams := obj["dnssec_key_params"].(map[string]interface{})
+	algorithmsField, rolloverField := "ksk_algorithms", "ksk_rollover"
+	if keyType == "ZSK" {
+		algorithmsField, rolloverField = "zsk_algorithms", "zsk_rollover"
+	}
+	algorithm := map[string]interface{}{"algorithm": "RSASHA256", "size": 1024}
+	if algorithms, ok := keyParams[algorithmsField].([]interface{}); ok && len(algorithms) > 0 {
+		algorithm = algorithms[0].(map[string]interface{})
+	}
+	publicKey := make([]byte, toInt64(algorithm["size"])/8+3)
+	rand.Read(publicKey)
+	publicKey[0] = 3
+	return map[string]interface{}{
+		"algorithm":       dnssecAlgorithmNumbers[valueString(algorithm["algorithm"])],
+		"public_key":      base64.StdEncoding.EncodeToString(publicKey),
+		"status":          "ACTIVE",
+		"tag":             int64(publicKey[1])<<8 | int64(publicKey[2]),
+		"type":            keyType,
+		"next_event_date": time.Now().Unix() + toInt64(keyParams[rolloverField]),
+	}
+}
+
+// toInt64 - converts a number decoded from JSON, or set by the server, to an int64
+func toInt64(value interface{}) int64 {
+	switch number := value.(type) {
+	case float64:
+		return int64(number)
+	case int:
+		return int64(number)
+	case int64:
+		return number
+	}
+	return 0
+}
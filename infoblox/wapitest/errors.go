@@ -0,0 +1,56 @@
+package wapitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// The codes of the WAPI errors the fake grid returns
+const (
+	codeProto    = "Client.Ibap.Proto"
+	codeNotFound = "Client.Ibap.Data.NotFound"
+	codeConflict = "Client.Ibap.Data.Conflict"
+	codeData     = "Client.Ibap.Data"
+)
+
+// errorClasses - the NIOS exception class prefixing the Error of each code
+var errorClasses = map[string]string{
+	codeProto:    "AdmConProtoError",
+	codeNotFound: "AdmConDataNotFoundError",
+	codeConflict: "AdmConDataError",
+	codeData:     "AdmConDataError",
+}
+
+// wapiError - a failed request, written as the {Error, code, text} body WAPI returns
+type wapiError struct {
+	status int
+	code   string
+	text   string
+}
+
+func errorf(status int, code, format string, args ...interface{}) *wapiError {
+	return &wapiError{status: status, code: code, text: fmt.Sprintf(format, args...)}
+}
+
+func (wapiErr *wapiError) Error() string {
+	return fmt.Sprintf("%s: %s", errorClasses[wapiErr.code], wapiErr.text)
+}
+
+func notFound(ref string) *wapiError {
+	return errorf(http.StatusNotFound, codeNotFound, "Reference %s not found", ref)
+}
+
+func unknownField(field string) *wapiError {
+	return errorf(http.StatusBadRequest, codeProto, "Unknown argument/field: '%s'", field)
+}
+
+func writeError(w http.ResponseWriter, wapiErr *wapiError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(wapiErr.status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"Error": wapiErr.Error(),
+		"code":  wapiErr.code,
+		"text":  wapiErr.text,
+	})
+}
@@ -0,0 +1,92 @@
+package wapitest
+
+import (
+	"net/http"
+)
+
+// resolveFunctions - replaces the object functions a write sends in place of field values, e.g.
+// {"_object_function": "next_available_ip", ...} for ipv4addr, with the value the function returns
+func (server *Server) resolveFunctions(obj object) *wapiError {
+	for field, value := range obj {
+		resolved, wapiErr := server.resolveValue(value)
+		if wapiErr != nil {
+			return wapiErr
+		}
+		obj[field] = resolved
+	}
+	return nil
+}
+
+func (server *Server) resolveValue(value interface{}) (interface{}, *wapiError) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if _, ok := typed["_object_function"]; ok {
+			return server.callObjectFunction(typed)
+		}
+		for key, element := range typed {
+			resolved, wapiErr := server.resolveValue(element)
+			if wapiErr != nil {
+				return nil, wapiErr
+			}
+			typed[key] = resolved
+		}
+	case []interface{}:
+		for i, element := range typed {
+			resolved, wapiErr := server.resolveValue(element)
+			if wapiErr != nil {
+				return nil, wapiErr
+			}
+			typed[i] = resolved
+		}
+	}
+	return value, nil
+}
+
+// callObjectFunction - calls the function of the single object of type _object matching _object_parameters and
+// returns the first value of its _result_field
+func (server *Server) callObjectFunction(call map[string]interface{}) (interface{}, *wapiError) {
+	functionName := valueString(call["_object_function"])
+	objectTypeName := valueString(call["_object"])
+	resultField := valueString(call["_result_field"])
+	objectType, ok := objectTypes[objectTypeName]
+	if !ok {
+		return nil, errorf(http.StatusBadRequest, codeProto, "Unknown object type (%s)", objectTypeName)
+	}
+	function, ok := objectType.functions[functionName]
+	if !ok {
+		return nil, errorf(http.StatusBadRequest, codeProto, "Function %s is not valid for this object", functionName)
+	}
+
+	objectParameters, _ := call["_object_parameters"].(map[string]interface{})
+	filters := make([]filter, 0, len(objectParameters))
+	for field, value := range objectParameters {
+		if !objectType.hasField(field) {
+			return nil, unknownField(field)
+		}
+		filters = append(filters, filter{field: field, value: valueString(value)})
+	}
+	var matches []object
+	for _, obj := range server.all(objectTypeName) {
+		if matchesFilters(obj, filters) {
+			matches = append(matches, obj)
+		}
+	}
+	if len(matches) != 1 {
+		return nil, errorf(http.StatusBadRequest, codeData, "The object function %s needs a single %s matching %v, %d found",
+			functionName, objectTypeName, objectParameters, len(matches))
+	}
+
+	parameters, _ := call["_parameters"].(map[string]interface{})
+	if parameters == nil {
+		parameters = make(map[string]interface{})
+	}
+	result, wapiErr := function(server, matches[0], parameters)
+	if wapiErr != nil {
+		return nil, wapiErr
+	}
+	values, _ := result[resultField].([]interface{})
+	if len(values) == 0 {
+		return nil, errorf(http.StatusBadRequest, codeProto, "Invalid value for _result_field: %s", resultField)
+	}
+	return values[0], nil
+}
@@ -0,0 +1,439 @@
+package wapitest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// descendantsActionKey - where the descendants actions of a write are kept until the write is applied to
+// the descendants. It isn't a field, so it is never returned.
+const descendantsActionKey = "_descendants_action"
+
+// prepareNetwork - canonicalizes the address of a network or network container, checks it doesn't clash with
+// the networks of its view and computes the container it belongs to
+func prepareNetwork(objectTypeName string) func(server *Server, obj, previous object) *wapiError {
+	return func(server *Server, obj, previous object) *wapiError {
+		ip, network, err := net.ParseCIDR(valueString(obj["network"]))
+		if err != nil || ip.To4() == nil {
+			return errorf(http.StatusBadRequest, codeProto, "Invalid value for network: %v", obj["network"])
+		}
+		if !ip.Equal(network.IP) {
+			return errorf(http.StatusBadRequest, codeData, "%s is an invalid network address, did you mean %s?", obj["network"], network)
+		}
+		obj["network"] = network.String()
+		if previous != nil && previous["network"] != obj["network"] {
+			return errorf(http.StatusBadRequest, codeProto, "Field is not writable: network")
+		}
+		networkView := valueString(obj["network_view"])
+		if server.findByName("networkview", networkView) == nil {
+			return errorf(http.StatusBadRequest, codeData, "Network view %s not found", networkView)
+		}
+
+		for _, otherType := range []string{"network", "networkcontainer"} {
+			for _, other := range server.all(otherType) {
+				if other["network_view"] != networkView || (previous != nil && other["_ref"] == previous["_ref"]) {
+					continue
+				}
+				_, otherNetwork, _ := net.ParseCIDR(valueString(other["network"]))
+				if !overlaps(network, otherNetwork) {
+					continue
+				}
+				if objectTypeName == "network" && (otherType == "network" || contains4(network, otherNetwork)) ||
+					objectTypeName == "networkcontainer" && (sameNetwork(network, otherNetwork) || otherType == "network" && contains4(otherNetwork, network)) {
+					return errorf(http.StatusBadRequest, codeData, "The network %s overlaps with the %s %s", network, otherType, otherNetwork)
+				}
+			}
+		}
+
+		container := server.parentContainer(network, networkView, obj)
+		obj["network_container"] = "/"
+		if container != nil {
+			obj["network_container"] = container["network"]
+		}
+		if objectTypeName == "network" {
+			ones, _ := network.Mask.Size()
+			obj["netmask"] = ones
+		}
+		return inheritExtAttrs(server, obj, previous, container)
+	}
+}
+
+// prepareIPv6Network - canonicalizes the address of an IPv6 network or network container and checks its view.
+// The provider only counts them, so they don't nest nor inherit extensible attributes.
+func prepareIPv6Network(server *Server, obj, previous object) *wapiError {
+	ip, network, err := net.ParseCIDR(valueString(obj["network"]))
+	if err != nil || ip.To4() != nil {
+		return errorf(http.StatusBadRequest, codeProto, "Invalid value for network: %v", obj["network"])
+	}
+	obj["network"] = network.String()
+	if previous != nil && previous["network"] != obj["network"] {
+		return errorf(http.StatusBadRequest, codeProto, "Field is not writable: network")
+	}
+	if networkView := valueString(obj["network_view"]); server.findByName("networkview", networkView) == nil {
+		return errorf(http.StatusBadRequest, codeData, "Network view %s not found", networkView)
+	}
+	return nil
+}
+
+// prepareRange - checks the addresses of a DHCP range and finds the network it belongs to
+func prepareRange(server *Server, obj, previous object) *wapiError {
+	start := net.ParseIP(valueString(obj["start_addr"])).To4()
+	end := net.ParseIP(valueString(obj["end_addr"])).To4()
+	if start == nil {
+		return errorf(http.StatusBadRequest, codeProto, "Invalid value for start_addr: %v", obj["start_addr"])
+	}
+	if end == nil {
+		return errorf(http.StatusBadRequest, codeProto, "Invalid value for end_addr: %v", obj["end_addr"])
+	}
+	if bytes.Compare(start, end) > 0 {
+		return errorf(http.StatusBadRequest, codeData, "The start address %s is after the end address %s", start, end)
+	}
+	networkView := valueString(obj["network_view"])
+	var parent object
+	for _, network := range server.all("network") {
+		_, ipNet, _ := net.ParseCIDR(valueString(network["network"]))
+		if network["network_view"] == networkView && ipNet.Contains(start) && ipNet.Contains(end) {
+			parent = network
+		}
+	}
+	if parent == nil {
+		return errorf(http.StatusBadRequest, codeData, "The range %s-%s is not in a network of the network view %s", start, end, networkView)
+	}
+	obj["network"] = parent["network"]
+	return inheritExtAttrs(server, obj, previous, parent)
+}
+
+// parentContainer - returns the smallest network container of the view strictly containing network
+func (server *Server) parentContainer(network *net.IPNet, networkView string, obj object) object {
+	var parent object
+	parentSize := -1
+	for _, container := range server.all("networkcontainer") {
+		_, containerNetwork, _ := net.ParseCIDR(valueString(container["network"]))
+		if container["network_view"] != networkView || sameNetwork(containerNetwork, network) || !contains4(containerNetwork, network) {
+			continue
+		}
+		if ones, _ := containerNetwork.Mask.Size(); ones > parentSize {
+			parent, parentSize = container, ones
+		}
+	}
+	return parent
+}
+
+// children - returns the networks, network containers and ranges whose parent is the given network or container
+func (server *Server) children(parent object) []object {
+	children := make([]object, 0)
+	if objectTypeName(parent) == "networkcontainer" {
+		for _, child := range append(server.all("networkcontainer"), server.all("network")...) {
+			if child["network_view"] == parent["network_view"] && child["network_container"] == parent["network"] {
+				children = append(children, child)
+			}
+		}
+	}
+	if objectTypeName(parent) == "network" {
+		for _, child := range server.all("range") {
+			if child["network_view"] == parent["network_view"] && child["network"] == parent["network"] {
+				children = append(children, child)
+			}
+		}
+	}
+	return children
+}
+
+// networkCreated - applies the descendants actions of a new network or container to the objects it now contains
+func networkCreated(server *Server, obj object) {
+	delete(obj, descendantsActionKey)
+}
+
+// extAttrsUpdated - applies the inheritable extensible attributes of an updated network or container to its
+// descendants, following the descendants actions of the update
+func extAttrsUpdated(server *Server, obj object, changed map[string]bool) {
+	actions, _ := obj[descendantsActionKey].(map[string]interface{})
+	delete(obj, descendantsActionKey)
+	if changed["extattrs"] {
+		server.applyExtAttrs(obj, obj, actions)
+	}
+}
+
+// applyExtAttrs - updates the values the descendants of parent inherit from source, parent itself or one of
+// its ancestors
+func (server *Server) applyExtAttrs(parent, source object, actions map[string]interface{}) {
+	extAttrs, _ := source["extattrs"].(map[string]interface{})
+	for _, child := range server.children(parent) {
+		childExtAttrs, _ := child["extattrs"].(map[string]interface{})
+		if childExtAttrs == nil {
+			childExtAttrs = make(map[string]interface{})
+			child["extattrs"] = childExtAttrs
+		}
+		for name, value := range childExtAttrs {
+			extAttr, _ := value.(map[string]interface{})
+			if _, ok := extAttrs[name]; ok || !inheritedFrom(extAttr, source) {
+				continue
+			}
+			action, _ := actions[name].(map[string]interface{})
+			if action["option_delete_ea"] == "RETAIN" {
+				delete(extAttr, "inheritance_source")
+			} else {
+				delete(childExtAttrs, name)
+			}
+		}
+		for name, value := range extAttrs {
+			extAttr, _ := value.(map[string]interface{})
+			if !server.inheritable(name) || extAttr["inheritance_source"] != nil {
+				continue
+			}
+			action, _ := actions[name].(map[string]interface{})
+			childExtAttr, ok := childExtAttrs[name].(map[string]interface{})
+			switch {
+			case !ok && action["option_without_ea"] == "NOT_INHERIT":
+				continue
+			case ok && !inheritedFrom(childExtAttr, source) && action["option_with_ea"] != "CONVERT" && action["option_with_ea"] != "INHERIT":
+				continue
+			}
+			childExtAttrs[name] = inheritedExtAttr(extAttr, source)
+		}
+		server.applyExtAttrs(child, source, actions)
+	}
+}
+
+// inheritExtAttrs - strips the descendants actions off the extensible attributes of a write, keeps the values
+// the object inherited and, on create, adds the inheritable values of its parent
+func inheritExtAttrs(server *Server, obj, previous, parent object) *wapiError {
+	extAttrs, _ := obj["extattrs"].(map[string]interface{})
+	if extAttrs == nil {
+		extAttrs = make(map[string]interface{})
+		obj["extattrs"] = extAttrs
+	}
+	actions := make(map[string]interface{})
+	for name, value := range extAttrs {
+		extAttr, ok := value.(map[string]interface{})
+		if !ok {
+			return errorf(http.StatusBadRequest, codeProto, "Invalid value for extattrs %s: %v", name, value)
+		}
+		if _, ok := extAttr["inheritance_source"]; ok && (previous == nil || !equalValues(previous["extattrs"].(map[string]interface{})[name], extAttr)) {
+			return errorf(http.StatusBadRequest, codeProto, "Field is not writable: inheritance_source")
+		}
+		if action, ok := extAttr["descendants_action"]; ok {
+			actions[name] = action
+			delete(extAttr, "descendants_action")
+		}
+	}
+	if len(actions) > 0 {
+		obj[descendantsActionKey] = actions
+	}
+
+	if previous != nil {
+		previousExtAttrs, _ := previous["extattrs"].(map[string]interface{})
+		for name, value := range previousExtAttrs {
+			if _, ok := extAttrs[name]; !ok && value.(map[string]interface{})["inheritance_source"] != nil {
+				extAttrs[name] = copyValue(value)
+			}
+		}
+		return nil
+	}
+	if parent == nil {
+		return nil
+	}
+	parentExtAttrs, _ := parent["extattrs"].(map[string]interface{})
+	for name, value := range parentExtAttrs {
+		if _, ok := extAttrs[name]; !ok && server.inheritable(name) {
+			extAttrs[name] = inheritedExtAttr(value.(map[string]interface{}), parent)
+		}
+	}
+	return nil
+}
+
+// inheritable - reports whether descendants inherit an extensible attribute: when its definition has the
+// I(nheritable) flag, or when it has no definition
+func (server *Server) inheritable(name string) bool {
+	definition := server.findByName("extensibleattributedef", name)
+	return definition == nil || strings.Contains(valueString(definition["flags"]), "I")
+}
+
+func inheritedExtAttr(extAttr map[string]interface{}, parent object) map[string]interface{} {
+	source := extAttr["inheritance_source"]
+	if source == nil {
+		source = map[string]interface{}{"_ref": parent["_ref"]}
+	}
+	return map[string]interface{}{"value": copyValue(extAttr["value"]), "inheritance_source": copyValue(source)}
+}
+
+func inheritedFrom(extAttr map[string]interface{}, parent object) bool {
+	source, ok := extAttr["inheritance_source"].(map[string]interface{})
+	return ok && idOf(valueString(source["_ref"])) == idOf(valueString(parent["_ref"]))
+}
+
+// removingNetwork - deletes the ranges of a network along with it
+func removingNetwork(server *Server, obj object, query map[string][]string) *wapiError {
+	for _, child := range server.children(obj) {
+		server.drop(idOf(child["_ref"].(string)))
+	}
+	return nil
+}
+
+// removingNetworkContainer - deletes the networks of a container along with it when remove_subnets is true,
+// the default, else moves them to the parent of the container
+func removingNetworkContainer(server *Server, obj object, query map[string][]string) *wapiError {
+	removeSubnets := firstValue(query, "remove_subnets") != "false"
+	for _, child := range server.children(obj) {
+		if removeSubnets {
+			childType := objectTypes[objectTypeName(child)]
+			childType.removing(server, child, query)
+			server.drop(idOf(child["_ref"].(string)))
+			continue
+		}
+		child["network_container"] = obj["network_container"]
+	}
+	return nil
+}
+
+// nextAvailableIP - the next_available_ip function of networks and ranges: the lowest addresses not used by
+// an A or host record, nor excluded
+func nextAvailableIP(server *Server, obj object, parameters map[string]interface{}) (map[string]interface{}, *wapiError) {
+	num, exclude, wapiErr := functionParameters(parameters)
+	if wapiErr != nil {
+		return nil, wapiErr
+	}
+	var first, last uint32
+	if objectTypeName(obj) == "range" {
+		first, last = ipToUint(net.ParseIP(valueString(obj["start_addr"]))), ipToUint(net.ParseIP(valueString(obj["end_addr"])))
+	} else {
+		_, network, _ := net.ParseCIDR(valueString(obj["network"]))
+		ones, bits := network.Mask.Size()
+		first = ipToUint(network.IP)
+		last = first + uint32(1)<<uint(bits-ones) - 1
+		if bits-ones > 1 {
+			first, last = first+1, last-1
+		}
+	}
+
+	used := make(map[string]bool)
+	for _, address := range exclude {
+		used[address] = true
+	}
+	for _, record := range server.all("record:a") {
+		used[valueString(record["ipv4addr"])] = true
+	}
+	for _, record := range server.all("record:host") {
+		for _, address := range fieldStrings(record, "ipv4addr") {
+			used[address] = true
+		}
+	}
+
+	ips := make([]interface{}, 0, num)
+	for address := first; address <= last && len(ips) < num; address++ {
+		if ip := uintToIP(address).String(); !used[ip] {
+			ips = append(ips, ip)
+		}
+		if address == last {
+			break
+		}
+	}
+	if len(ips) < num {
+		return nil, errorf(http.StatusBadRequest, codeData, "Cannot find %d available IP address(es) in %s", num, obj["_ref"])
+	}
+	return map[string]interface{}{"ips": ips}, nil
+}
+
+// nextAvailableNetwork - the next_available_network function of network containers: the lowest networks of the
+// requested size not overlapping the networks of the container, nor excluded
+func nextAvailableNetwork(server *Server, obj object, parameters map[string]interface{}) (map[string]interface{}, *wapiError) {
+	num, exclude, wapiErr := functionParameters(parameters)
+	if wapiErr != nil {
+		return nil, wapiErr
+	}
+	cidr, ok := parameters["cidr"].(float64)
+	_, container, _ := net.ParseCIDR(valueString(obj["network"]))
+	containerOnes, _ := container.Mask.Size()
+	if !ok || int(cidr) <= containerOnes || cidr > 32 {
+		return nil, errorf(http.StatusBadRequest, codeProto, "Invalid value for cidr: %v", parameters["cidr"])
+	}
+
+	taken := make([]*net.IPNet, 0)
+	for _, network := range exclude {
+		_, excluded, err := net.ParseCIDR(network)
+		if err != nil {
+			return nil, errorf(http.StatusBadRequest, codeProto, "Invalid value for exclude: %s", network)
+		}
+		taken = append(taken, excluded)
+	}
+	for _, objectTypeName := range []string{"network", "networkcontainer"} {
+		for _, other := range server.all(objectTypeName) {
+			_, otherNetwork, _ := net.ParseCIDR(valueString(other["network"]))
+			if other["network_view"] == obj["network_view"] && !sameNetwork(otherNetwork, container) {
+				taken = append(taken, otherNetwork)
+			}
+		}
+	}
+
+	networks := make([]interface{}, 0, num)
+	size := uint64(1) << uint(32-int(cidr))
+	end := uint64(ipToUint(container.IP)) + uint64(1)<<uint(32-containerOnes)
+	for address := uint64(ipToUint(container.IP)); address < end && len(networks) < num; address += size {
+		candidate := &net.IPNet{IP: uintToIP(uint32(address)), Mask: net.CIDRMask(int(cidr), 32)}
+		free := true
+		for _, network := range taken {
+			if overlaps(candidate, network) {
+				free = false
+				break
+			}
+		}
+		if free {
+			networks = append(networks, candidate.String())
+		}
+	}
+	if len(networks) < num {
+		return nil, errorf(http.StatusBadRequest, codeData, "Cannot find %d available network(s) of size /%d in %s", num, int(cidr), obj["network"])
+	}
+	return map[string]interface{}{"networks": networks}, nil
+}
+
+// functionParameters - returns the num and exclude parameters of the next available functions
+func functionParameters(parameters map[string]interface{}) (int, []string, *wapiError) {
+	num := 1
+	if value, ok := parameters["num"]; ok {
+		number, ok := value.(float64)
+		if !ok || number < 1 {
+			return 0, nil, errorf(http.StatusBadRequest, codeProto, "Invalid value for num: %v", value)
+		}
+		num = int(number)
+	}
+	exclude := make([]string, 0)
+	if value, ok := parameters["exclude"]; ok {
+		list, ok := value.([]interface{})
+		if !ok {
+			return 0, nil, errorf(http.StatusBadRequest, codeProto, "Invalid value for exclude: %v", value)
+		}
+		for _, element := range list {
+			exclude = append(exclude, valueString(element))
+		}
+	}
+	return num, exclude, nil
+}
+
+func overlaps(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+func sameNetwork(a, b *net.IPNet) bool {
+	return a.String() == b.String()
+}
+
+// contains4 - reports whether network a contains network b
+func contains4(a, b *net.IPNet) bool {
+	aOnes, _ := a.Mask.Size()
+	bOnes, _ := b.Mask.Size()
+	return a.Contains(b.IP) && aOnes <= bOnes
+}
+
+func ipToUint(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
+}
+
+func uintToIP(address uint32) net.IP {
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, address)
+	return ip
+}
@@ -0,0 +1,838 @@
+package wapitest
+
+import (
+	"fmt"
+	"github.com/sky-uk/terraform-provider-infoblox/infoblox/util"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// objectType - how the fake grid handles the objects of a WAPI type
+type objectType struct {
+	name string
+	// since - the first WAPI version offering the type, empty when every version does. The fake grid picks
+	// its own versions, so tests can configure one lacking a type or field, they don't track NIOS releases.
+	since string
+	// fields - the fields of the type listed by its _schema
+	fields []string
+	// fieldsSince - the first WAPI version offering the fields added to the type after it
+	fieldsSince map[string]string
+	// readOnly - the fields the grid computes, which can't be written
+	readOnly []string
+	// writeOnly - the fields which are never returned, e.g. passwords
+	writeOnly []string
+	// searchOnly - search arguments which aren't fields, e.g. ipv4addr of record:host
+	searchOnly []string
+	// required - the fields a create must set
+	required []string
+	// basicFields - the fields returned when a request doesn't ask for any
+	basicFields []string
+	// defaults - the values of the fields a create doesn't set
+	defaults map[string]interface{}
+	// refFields - the fields making the readable part of references
+	refFields []string
+	// keyFields - the fields identifying an object, a create or update duplicating them conflicts
+	keyFields []string
+	// prepare - validates an object and computes its read only fields before it is stored,
+	// previous is nil on create
+	prepare func(server *Server, obj, previous object) *wapiError
+	// created - runs once a new object is stored
+	created func(server *Server, obj object)
+	// updated - runs once an update is stored, with the fields the update set
+	updated func(server *Server, obj object, changed map[string]bool)
+	// removing - runs before an object is deleted, with the query of the DELETE
+	removing func(server *Server, obj object, query map[string][]string) *wapiError
+	// functions - the functions which can be called on the objects of the type
+	functions map[string]function
+}
+
+// function - a WAPI function called on an object, either with ?_function= or as an object function
+type function func(server *Server, obj object, parameters map[string]interface{}) (map[string]interface{}, *wapiError)
+
+func (objectType *objectType) hasField(field string) bool {
+	return contains(objectType.fields, field)
+}
+
+// inVersion - tells whether the type is offered by the given WAPI version
+func (objectType *objectType) inVersion(version string) bool {
+	return objectType.since == "" || util.CompareWapiVersions(version, objectType.since) >= 0
+}
+
+// hasFieldIn - tells whether the type has the field in the given WAPI version
+func (objectType *objectType) hasFieldIn(field, version string) bool {
+	since, ok := objectType.fieldsSince[field]
+	return objectType.hasField(field) && (!ok || util.CompareWapiVersions(version, since) >= 0)
+}
+
+func (objectType *objectType) hasSearchAlias(field string) bool {
+	return contains(objectType.searchOnly, field)
+}
+
+func (objectType *objectType) isReadOnly(field string) bool {
+	return contains(objectType.readOnly, field)
+}
+
+func (objectType *objectType) isWriteOnly(field string) bool {
+	return contains(objectType.writeOnly, field)
+}
+
+// display - returns the readable part of the references of an object, e.g. foo.example.com/default
+func (objectType *objectType) display(obj object) string {
+	parts := make([]string, 0, len(objectType.refFields))
+	for _, field := range objectType.refFields {
+		parts = append(parts, valueString(obj[field]))
+	}
+	return strings.Join(parts, "/")
+}
+
+func (objectType *objectType) ref(id string, obj object) string {
+	return fmt.Sprintf("%s/%s:%s", objectType.name, id, objectType.display(obj))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// objectTypes - the WAPI object types the fake grid supports, keyed by name
+var objectTypes = make(map[string]*objectType)
+
+// seed - an object a new grid has, with the values of its read only fields
+type seed struct {
+	objectType string
+	fields     map[string]interface{}
+	computed   map[string]interface{}
+}
+
+var seedObjects = []seed{
+	{"networkview", map[string]interface{}{"name": "default"}, map[string]interface{}{"is_default": true}},
+	{"view", map[string]interface{}{"name": "default"}, map[string]interface{}{"is_default": true}},
+}
+
+// discoveryFieldsSince - the network discovery fields, which came after networks and network containers
+var discoveryFieldsSince = map[string]string{"enable_discovery": "2.3", "use_enable_discovery": "2.3", "discovery_member": "2.3"}
+
+func init() {
+	recordFields := []string{"name", "view", "zone", "ttl", "use_ttl", "comment", "disable", "creator", "extattrs"}
+	recordDefaults := map[string]interface{}{"view": "default", "use_ttl": false, "disable": false, "creator": "STATIC", "extattrs": map[string]interface{}{}}
+
+	for _, objectType := range []*objectType{
+		{
+			name:        "record:a",
+			fields:      append([]string{"ipv4addr"}, recordFields...),
+			readOnly:    []string{"zone"},
+			required:    []string{"name", "ipv4addr"},
+			basicFields: []string{"ipv4addr", "name", "view"},
+			defaults:    recordDefaults,
+			refFields:   []string{"name", "view"},
+			keyFields:   []string{"name", "ipv4addr", "view"},
+			prepare:     prepareRecord("ipv4addr"),
+		},
+		{
+			name:        "record:aaaa",
+			fields:      append([]string{"ipv6addr"}, recordFields...),
+			readOnly:    []string{"zone"},
+			required:    []string{"name", "ipv6addr"},
+			basicFields: []string{"ipv6addr", "name", "view"},
+			defaults:    recordDefaults,
+			refFields:   []string{"name", "view"},
+			keyFields:   []string{"name", "ipv6addr", "view"},
+			prepare:     prepareRecord("ipv6addr"),
+		},
+		{
+			name:        "record:cname",
+			fields:      append([]string{"canonical"}, recordFields...),
+			readOnly:    []string{"zone"},
+			required:    []string{"name", "canonical"},
+			basicFields: []string{"canonical", "name", "view"},
+			defaults:    recordDefaults,
+			refFields:   []string{"name", "view"},
+			keyFields:   []string{"name", "view"},
+			prepare:     prepareRecord(),
+		},
+		{
+			name:        "record:mx",
+			fields:      append([]string{"mail_exchanger", "preference"}, recordFields...),
+			readOnly:    []string{"zone"},
+			required:    []string{"name", "mail_exchanger", "preference"},
+			basicFields: []string{"mail_exchanger", "name", "preference", "view"},
+			defaults:    recordDefaults,
+			refFields:   []string{"name", "view"},
+			keyFields:   []string{"name", "mail_exchanger", "preference", "view"},
+			prepare:     prepareRecord(),
+		},
+		{
+			name:        "record:srv",
+			fields:      append([]string{"port", "priority", "target", "weight"}, recordFields...),
+			readOnly:    []string{"zone"},
+			required:    []string{"name", "port", "priority", "target", "weight"},
+			basicFields: []string{"name", "port", "priority", "target", "view", "weight"},
+			defaults:    recordDefaults,
+			refFields:   []string{"name", "view"},
+			keyFields:   []string{"name", "port", "priority", "target", "weight", "view"},
+			prepare:     prepareRecord(),
+		},
+		{
+			name:        "record:txt",
+			fields:      append([]string{"text"}, recordFields...),
+			readOnly:    []string{"zone"},
+			required:    []string{"name", "text"},
+			basicFields: []string{"name", "text", "view"},
+			defaults:    recordDefaults,
+			refFields:   []string{"name", "view"},
+			keyFields:   []string{"name", "text", "view"},
+			prepare:     prepareRecord(),
+		},
+		{
+			name:        "record:ptr",
+			fields:      append([]string{"ptrdname", "ipv4addr", "ipv6addr"}, recordFields...),
+			readOnly:    []string{"zone"},
+			required:    []string{"ptrdname"},
+			basicFields: []string{"ptrdname", "view"},
+			defaults:    recordDefaults,
+			refFields:   []string{"name", "view"},
+			keyFields:   []string{"name", "ptrdname", "view"},
+			prepare:     preparePTRRecord,
+		},
+		{
+			name:        "record:ns",
+			fields:      []string{"name", "nameserver", "addresses", "view", "zone", "ms_delegation_name", "creator"},
+			readOnly:    []string{"zone"},
+			required:    []string{"name", "nameserver", "addresses"},
+			basicFields: []string{"name", "nameserver", "view"},
+			defaults:    map[string]interface{}{"view": "default", "creator": "STATIC"},
+			refFields:   []string{"name", "view"},
+			keyFields:   []string{"name", "nameserver", "view"},
+			prepare:     prepareRecord(),
+		},
+		{
+			name: "record:host",
+			fields: []string{"name", "view", "zone", "comment", "ttl", "use_ttl", "disable", "configure_for_dns", "aliases",
+				"ipv4addrs", "ipv6addrs", "extattrs"},
+			readOnly:    []string{"zone"},
+			searchOnly:  []string{"ipv4addr", "mac"},
+			required:    []string{"name", "ipv4addrs"},
+			basicFields: []string{"ipv4addrs", "name", "view"},
+			defaults: map[string]interface{}{"view": "default", "use_ttl": false, "disable": false, "configure_for_dns": true,
+				"aliases": []interface{}{}, "extattrs": map[string]interface{}{}},
+			refFields: []string{"name", "view"},
+			keyFields: []string{"name", "view"},
+			prepare:   prepareHostRecord,
+		},
+		{
+			name: "network",
+			fields: []string{"network", "network_view", "network_container", "comment", "authority", "use_authority",
+				"auto_create_reversezone", "disable", "enable_ddns", "use_enable_ddns", "enable_dhcp_thresholds",
+				"use_enable_dhcp_thresholds", "high_water_mark", "high_water_mark_reset", "low_water_mark", "low_water_mark_reset",
+				"enable_discovery", "use_enable_discovery", "discovery_member", "ipv4addr", "lease_scavenge_time",
+				"use_lease_scavenge_time", "netmask", "members", "options", "use_options", "recycle_leases", "use_recycle_leases",
+				"restart_if_needed", "update_dns_on_lease_renewal", "use_update_dns_on_lease_renewal", "use_blackout_setting",
+				"use_discovery_basic_polling_settings", "use_email_list", "use_enable_ifmap_publishing",
+				"use_ignore_dhcp_option_list_request", "use_ignore_id", "use_ipam_email_addresses", "use_ipam_threshold_settings",
+				"use_ipam_trap_settings", "use_logic_filter_rules", "use_nextserver", "use_pxe_lease_time", "use_subscribe_settings",
+				"use_zone_associations", "zone_associations", "extattrs"},
+			fieldsSince: discoveryFieldsSince,
+			readOnly:    []string{"network_container"},
+			writeOnly:   []string{"auto_create_reversezone", "restart_if_needed"},
+			required:    []string{"network"},
+			basicFields: []string{"comment", "network", "network_view"},
+			defaults: map[string]interface{}{"network_view": "default", "authority": false, "disable": false, "enable_ddns": false,
+				"enable_dhcp_thresholds": false, "high_water_mark": 95, "high_water_mark_reset": 85, "low_water_mark": 0,
+				"low_water_mark_reset": 10, "enable_discovery": false, "lease_scavenge_time": -1, "recycle_leases": true,
+				"update_dns_on_lease_renewal": false, "members": []interface{}{}, "options": []interface{}{},
+				"zone_associations": []interface{}{}, "extattrs": map[string]interface{}{}},
+			refFields: []string{"network", "network_view"},
+			keyFields: []string{"network", "network_view"},
+			prepare:   prepareNetwork("network"),
+			created:   networkCreated,
+			updated:   extAttrsUpdated,
+			removing:  removingNetwork,
+			functions: map[string]function{"next_available_ip": nextAvailableIP},
+		},
+		{
+			name: "networkcontainer",
+			fields: []string{"network", "network_view", "network_container", "comment", "authority", "use_authority",
+				"enable_ddns", "use_enable_ddns", "enable_discovery", "use_enable_discovery", "discovery_member", "options",
+				"use_options", "extattrs"},
+			fieldsSince: discoveryFieldsSince,
+			readOnly:    []string{"network_container"},
+			required:    []string{"network"},
+			basicFields: []string{"comment", "network", "network_view"},
+			defaults: map[string]interface{}{"network_view": "default", "authority": false, "enable_ddns": false,
+				"enable_discovery": false, "options": []interface{}{}, "extattrs": map[string]interface{}{}},
+			refFields: []string{"network", "network_view"},
+			keyFields: []string{"network", "network_view"},
+			prepare:   prepareNetwork("networkcontainer"),
+			created:   networkCreated,
+			updated:   extAttrsUpdated,
+			removing:  removingNetworkContainer,
+			functions: map[string]function{"next_available_network": nextAvailableNetwork},
+		},
+		{
+			name:        "ipv6network",
+			fields:      []string{"network", "network_view", "comment", "extattrs"},
+			required:    []string{"network"},
+			basicFields: []string{"comment", "network", "network_view"},
+			defaults:    map[string]interface{}{"network_view": "default", "extattrs": map[string]interface{}{}},
+			refFields:   []string{"network", "network_view"},
+			keyFields:   []string{"network", "network_view"},
+			prepare:     prepareIPv6Network,
+		},
+		{
+			name:        "ipv6networkcontainer",
+			fields:      []string{"network", "network_view", "comment", "extattrs"},
+			required:    []string{"network"},
+			basicFields: []string{"comment", "network", "network_view"},
+			defaults:    map[string]interface{}{"network_view": "default", "extattrs": map[string]interface{}{}},
+			refFields:   []string{"network", "network_view"},
+			keyFields:   []string{"network", "network_view"},
+			prepare:     prepareIPv6Network,
+		},
+		{
+			name: "range",
+			fields: []string{"start_addr", "end_addr", "network", "network_view", "name", "comment", "disable", "member",
+				"server_association_type", "restart_if_needed", "extattrs"},
+			writeOnly:   []string{"restart_if_needed"},
+			required:    []string{"start_addr", "end_addr"},
+			basicFields: []string{"comment", "end_addr", "network", "network_view", "start_addr"},
+			defaults: map[string]interface{}{"network_view": "default", "disable": false, "server_association_type": "NONE",
+				"extattrs": map[string]interface{}{}},
+			refFields: []string{"start_addr", "end_addr", "network_view"},
+			keyFields: []string{"start_addr", "end_addr", "network_view"},
+			prepare:   prepareRange,
+			created:   networkCreated,
+			functions: map[string]function{"next_available_ip": nextAvailableIP},
+		},
+		{
+			name: "zone_auth",
+			fields: []string{"fqdn", "view", "comment", "zone_format", "prefix", "address", "disable", "locked", "locked_by",
+				"dns_fqdn", "display_domain", "parent", "network_view", "ns_group", "grid_primary", "grid_secondaries",
+				"external_primaries", "external_secondaries", "grid_primary_shared_with_ms_parent_delegation", "ms_primaries",
+				"ms_secondaries", "soa_serial_number", "set_soa_serial_number", "soa_default_ttl", "soa_negative_ttl",
+				"soa_refresh", "soa_retry", "soa_expire", "use_grid_zone_timer", "soa_email", "use_soa_email", "dns_soa_email",
+				"copy_xfer_to_notify", "use_copy_xfer_to_notify", "notify_delay", "use_notify_delay", "dns_integrity_enable",
+				"dns_integrity_frequency", "dns_integrity_member", "dns_integrity_verbose_logging", "allow_query",
+				"use_allow_query", "allow_transfer", "use_allow_transfer", "allow_update", "use_allow_update",
+				"update_forwarding", "allow_update_forwarding", "use_allow_update_forwarding", "allow_active_dir",
+				"use_allow_active_dir", "allow_gss_tsig_for_underscore_zone", "allow_gss_tsig_zone_updates",
+				"create_ptr_for_bulk_hosts", "create_ptr_for_hosts", "create_underscore_zones", "ddns_principal_group",
+				"ddns_principal_tracking", "ddns_restrict_patterns", "ddns_restrict_patterns_list", "ddns_restrict_protected",
+				"ddns_restrict_secure", "ddns_restrict_static", "use_ddns_patterns_restriction", "use_ddns_principal_security",
+				"use_ddns_restrict_protected", "use_ddns_restrict_static", "disable_forwarding", "do_host_abstraction",
+				"effective_check_names_policy", "use_check_names_policy", "effective_record_name_policy", "record_name_policy",
+				"use_record_name_policy", "import_from", "use_import_from", "is_multimaster", "primary_type", "mask_prefix",
+				"member_soa_mnames", "member_soa_serials", "ms_ad_integrated", "ms_allow_transfer", "ms_allow_transfer_mode",
+				"ms_dc_ns_record_creation", "ms_ddns_mode", "ms_managed", "ms_read_only", "ms_sync_disabled",
+				"ms_sync_master_name", "network_associations", "records_monitored", "rr_not_queried_enabled_time",
+				"zone_not_queried_enabled_time", "last_queried", "scavenging_settings", "use_scavenging_settings",
+				"srgs", "using_srg_associations", "cloud_info", "restart_if_needed", "is_dnssec_enabled", "is_dnssec_signed",
+				"dnssec_key_params", "use_dnssec_key_params", "dnssec_keys", "dnssec_ksk_rollover_date",
+				"dnssec_zsk_rollover_date", "extattrs"},
+			fieldsSince: map[string]string{"update_forwarding": "2.3", "allow_update_forwarding": "2.3",
+				"use_allow_update_forwarding": "2.3", "dnssec_key_params": "2.3", "use_dnssec_key_params": "2.3",
+				"dnssec_keys": "2.3", "dnssec_ksk_rollover_date": "2.3", "dnssec_zsk_rollover_date": "2.3"},
+			readOnly: []string{"locked_by", "dns_fqdn", "display_domain", "parent", "network_associations",
+				"effective_check_names_policy", "effective_record_name_policy", "is_multimaster", "primary_type",
+				"member_soa_serials", "ms_managed", "ms_read_only", "ms_sync_master_name", "records_monitored", "last_queried",
+				"cloud_info", "is_dnssec_enabled", "is_dnssec_signed", "dnssec_keys", "dnssec_ksk_rollover_date",
+				"dnssec_zsk_rollover_date", "using_srg_associations"},
+			writeOnly:   []string{"restart_if_needed", "set_soa_serial_number"},
+			required:    []string{"fqdn"},
+			basicFields: []string{"fqdn", "view"},
+			defaults: map[string]interface{}{"view": "default", "zone_format": "FORWARD", "disable": false, "locked": false,
+				"network_view": "default", "grid_primary": []interface{}{}, "grid_secondaries": []interface{}{},
+				"external_primaries": []interface{}{}, "external_secondaries": []interface{}{}, "soa_serial_number": 1,
+				"soa_default_ttl": 28800, "soa_negative_ttl": 900, "soa_refresh": 10800, "soa_retry": 3600,
+				"soa_expire": 2419200, "use_grid_zone_timer": false, "copy_xfer_to_notify": false,
+				"use_copy_xfer_to_notify": false, "dns_integrity_enable": false, "allow_query": []interface{}{},
+				"use_allow_query": false, "allow_transfer": []interface{}{}, "use_allow_transfer": false,
+				"allow_update": []interface{}{}, "use_allow_update": false, "update_forwarding": []interface{}{},
+				"allow_update_forwarding": false, "use_allow_update_forwarding": false, "use_check_names_policy": false,
+				"is_dnssec_enabled": false, "is_dnssec_signed": false, "dnssec_keys": []interface{}{},
+				"use_dnssec_key_params": false, "extattrs": map[string]interface{}{}},
+			refFields: []string{"fqdn", "view"},
+			keyFields: []string{"fqdn", "view"},
+			prepare:   prepareZoneAuth,
+			removing:  removingZone,
+			functions: map[string]function{"dnssec_operation": dnssecOperation},
+		},
+		{
+			name: "zone_delegated",
+			fields: []string{"fqdn", "view", "comment", "zone_format", "prefix", "address", "disable", "locked", "dns_fqdn",
+				"delegate_to", "delegated_ttl", "use_delegated_ttl", "enable_rfc2317_exclusion", "ns_group", "extattrs"},
+			readOnly:    []string{"dns_fqdn"},
+			required:    []string{"fqdn"},
+			basicFields: []string{"delegate_to", "fqdn", "view"},
+			defaults: map[string]interface{}{"view": "default", "zone_format": "FORWARD", "disable": false, "locked": false,
+				"delegate_to": []interface{}{}, "use_delegated_ttl": false, "extattrs": map[string]interface{}{}},
+			refFields: []string{"fqdn", "view"},
+			keyFields: []string{"fqdn", "view"},
+			prepare:   prepareZone,
+		},
+		{
+			name: "zone_forward",
+			fields: []string{"fqdn", "view", "comment", "zone_format", "prefix", "address", "disable", "locked", "locked_by",
+				"dns_fqdn", "display_domain", "parent", "forward_to", "forwarders_only", "forwarding_servers", "mask_prefix",
+				"ms_ad_integrated", "ms_ddns_mode", "ms_managed", "ms_read_only", "ms_sync_master_name", "ns_group",
+				"using_srg_associations", "extattrs"},
+			readOnly: []string{"locked_by", "dns_fqdn", "display_domain", "parent", "ms_managed", "ms_read_only",
+				"ms_sync_master_name", "using_srg_associations"},
+			required:    []string{"fqdn", "forward_to"},
+			basicFields: []string{"forward_to", "fqdn", "view"},
+			defaults: map[string]interface{}{"view": "default", "zone_format": "FORWARD", "disable": false, "locked": false,
+				"forwarders_only": false, "forwarding_servers": []interface{}{}, "ms_ad_integrated": false,
+				"ms_ddns_mode": "NONE", "ms_managed": "NONE", "ms_read_only": false, "using_srg_associations": false,
+				"extattrs": map[string]interface{}{}},
+			refFields: []string{"fqdn", "view"},
+			keyFields: []string{"fqdn", "view"},
+			prepare:   prepareZone,
+		},
+		{
+			name: "zone_stub",
+			fields: []string{"fqdn", "view", "comment", "zone_format", "prefix", "disable", "disable_forwarding", "locked",
+				"mask_prefix", "ns_group", "external_ns_group", "stub_from", "stub_members", "using_srg_associations", "extattrs"},
+			readOnly:    []string{"using_srg_associations"},
+			required:    []string{"fqdn", "stub_from"},
+			basicFields: []string{"fqdn", "stub_from", "view"},
+			defaults: map[string]interface{}{"view": "default", "zone_format": "FORWARD", "disable": false,
+				"disable_forwarding": false, "locked": false, "stub_members": []interface{}{}, "extattrs": map[string]interface{}{}},
+			refFields: []string{"fqdn", "view"},
+			keyFields: []string{"fqdn", "view"},
+			prepare:   prepareZone,
+		},
+		{
+			name:        "nsgroup:delegation",
+			fields:      []string{"name", "comment", "delegate_to"},
+			required:    []string{"name", "delegate_to"},
+			basicFields: []string{"delegate_to", "name"},
+			refFields:   []string{"name"},
+			keyFields:   []string{"name"},
+		},
+		{
+			name:        "adminuser",
+			fields:      []string{"name", "password", "email", "comment", "admin_groups", "disable", "extattrs"},
+			writeOnly:   []string{"password"},
+			required:    []string{"name", "password", "admin_groups"},
+			basicFields: []string{"admin_groups", "comment", "name"},
+			defaults:    map[string]interface{}{"disable": false, "extattrs": map[string]interface{}{}},
+			refFields:   []string{"name"},
+			keyFields:   []string{"name"},
+		},
+		{
+			name:        "admingroup",
+			fields:      []string{"name", "comment", "disable", "superuser", "roles", "email_addresses", "access_method", "extattrs"},
+			required:    []string{"name"},
+			basicFields: []string{"comment", "name"},
+			defaults: map[string]interface{}{"disable": false, "superuser": false, "roles": []interface{}{},
+				"email_addresses": []interface{}{}, "access_method": []interface{}{"GUI", "API", "TAXII"},
+				"extattrs": map[string]interface{}{}},
+			refFields: []string{"name"},
+			keyFields: []string{"name"},
+		},
+		{
+			name:        "adminrole",
+			fields:      []string{"name", "comment", "disable", "extattrs"},
+			required:    []string{"name"},
+			basicFields: []string{"comment", "name"},
+			defaults:    map[string]interface{}{"disable": false, "extattrs": map[string]interface{}{}},
+			refFields:   []string{"name"},
+			keyFields:   []string{"name"},
+		},
+		{
+			name:        "permission",
+			fields:      []string{"group", "role", "object", "resource_type", "permission"},
+			required:    []string{"permission"},
+			basicFields: []string{"group", "permission", "resource_type", "role"},
+			refFields:   []string{"role", "group", "resource_type", "object"},
+			keyFields:   []string{"group", "role", "object", "resource_type"},
+			prepare:     preparePermission,
+		},
+		{
+			name: "extensibleattributedef",
+			fields: []string{"name", "type", "comment", "default_value", "flags", "list_values", "min", "max",
+				"allowed_object_types"},
+			fieldsSince: map[string]string{"allowed_object_types": "2.3"},
+			required:    []string{"name", "type"},
+			basicFields: []string{"comment", "default_value", "name", "type"},
+			refFields:   []string{"name"},
+			keyFields:   []string{"name"},
+		},
+		{
+			name: "view",
+			fields: []string{"name", "comment", "network_view", "is_default", "disable", "match_clients",
+				"match_destinations", "recursion", "use_recursion", "forwarders", "forward_only", "use_forwarders",
+				"lame_ttl", "use_lame_ttl", "dnssec_enabled", "dnssec_validation_enabled",
+				"dnssec_expired_signatures_enabled", "use_dnssec", "extattrs"},
+			readOnly:    []string{"is_default"},
+			required:    []string{"name"},
+			basicFields: []string{"is_default", "name"},
+			defaults: map[string]interface{}{"network_view": "default", "is_default": false, "disable": false,
+				"match_clients": []interface{}{}, "match_destinations": []interface{}{}, "recursion": false,
+				"use_recursion": false, "forwarders": []interface{}{}, "forward_only": false, "use_forwarders": false,
+				"lame_ttl": 600, "use_lame_ttl": false, "dnssec_enabled": false, "dnssec_validation_enabled": true,
+				"dnssec_expired_signatures_enabled": false, "use_dnssec": false, "extattrs": map[string]interface{}{}},
+			refFields: []string{"name", "is_default"},
+			keyFields: []string{"name"},
+			prepare:   prepareView,
+			created:   viewCreated,
+			updated:   viewUpdated,
+			removing:  removingView,
+		},
+		{
+			name:        "networkview",
+			fields:      []string{"name", "comment", "is_default", "associated_dns_views", "extattrs"},
+			readOnly:    []string{"is_default", "associated_dns_views"},
+			required:    []string{"name"},
+			basicFields: []string{"is_default", "name"},
+			defaults: map[string]interface{}{"is_default": false, "associated_dns_views": []interface{}{},
+				"extattrs": map[string]interface{}{}},
+			refFields: []string{"name", "is_default"},
+			keyFields: []string{"name"},
+			removing:  removingNetworkView,
+		},
+		{
+			name:        "namedacl",
+			since:       "2.3",
+			fields:      []string{"name", "comment", "access_list", "exploded_access_list", "extattrs"},
+			readOnly:    []string{"exploded_access_list"},
+			required:    []string{"name"},
+			basicFields: []string{"comment", "name"},
+			defaults:    map[string]interface{}{"access_list": []interface{}{}, "extattrs": map[string]interface{}{}},
+			refFields:   []string{"name"},
+			keyFields:   []string{"name"},
+			prepare:     prepareNamedACL,
+		},
+	} {
+		objectTypes[objectType.name] = objectType
+	}
+}
+
+// prepareRecord - validates the view and addresses of a DNS record and computes its zone
+func prepareRecord(addressFields ...string) func(server *Server, obj, previous object) *wapiError {
+	return func(server *Server, obj, previous object) *wapiError {
+		for _, field := range addressFields {
+			if wapiErr := checkAddress(obj, field); wapiErr != nil {
+				return wapiErr
+			}
+		}
+		if wapiErr := server.checkView(obj); wapiErr != nil {
+			return wapiErr
+		}
+		obj["zone"] = server.zoneOf(valueString(obj["name"]), valueString(obj["view"]))
+		return nil
+	}
+}
+
+// preparePTRRecord - computes the name of a PTR record from its address when not set
+func preparePTRRecord(server *Server, obj, previous object) *wapiError {
+	if wapiErr := checkAddress(obj, "ipv4addr"); wapiErr != nil {
+		return wapiErr
+	}
+	if wapiErr := checkAddress(obj, "ipv6addr"); wapiErr != nil {
+		return wapiErr
+	}
+	for _, field := range []string{"ipv4addr", "ipv6addr"} {
+		if address, ok := obj[field].(string); ok && address != "" {
+			if previous == nil || previous[field] != address || obj["name"] == nil {
+				obj["name"] = reverseName(net.ParseIP(address))
+			}
+		}
+	}
+	if valueString(obj["name"]) == "" {
+		return errorf(http.StatusBadRequest, codeProto, "Field is required: name, ipv4addr or ipv6addr")
+	}
+	return prepareRecord()(server, obj, previous)
+}
+
+// prepareHostRecord - validates the addresses of a host record and fills in their references
+func prepareHostRecord(server *Server, obj, previous object) *wapiError {
+	addresses, _ := obj["ipv4addrs"].([]interface{})
+	if len(addresses) == 0 {
+		return errorf(http.StatusBadRequest, codeProto, "Field is required: ipv4addrs")
+	}
+	for i, value := range addresses {
+		address, ok := value.(map[string]interface{})
+		if !ok {
+			return errorf(http.StatusBadRequest, codeProto, "Invalid value for ipv4addrs: %v", value)
+		}
+		if net.ParseIP(valueString(address["ipv4addr"])).To4() == nil {
+			return errorf(http.StatusBadRequest, codeProto, "Invalid value for ipv4addr: %v", address["ipv4addr"])
+		}
+		if _, ok := address["configure_for_dhcp"]; !ok {
+			address["configure_for_dhcp"] = false
+		}
+		address["host"] = obj["name"]
+		address["_ref"] = fmt.Sprintf("record:host_ipv4addr/%s:%s/%s/%s", hostAddressID(obj, i),
+			address["ipv4addr"], valueString(obj["name"]), valueString(obj["view"]))
+	}
+	return prepareRecord()(server, obj, previous)
+}
+
+func hostAddressID(obj object, i int) string {
+	return strings.Replace(fmt.Sprintf("%s$%d", valueString(obj["name"]), i), ".", "$", -1)
+}
+
+// prepareZone - validates the view of a zone and normalizes its fqdn
+func prepareZone(server *Server, obj, previous object) *wapiError {
+	if wapiErr := server.checkView(obj); wapiErr != nil {
+		return wapiErr
+	}
+	fqdn := valueString(obj["fqdn"])
+	switch valueString(obj["zone_format"]) {
+	case "FORWARD":
+		obj["fqdn"] = strings.TrimSuffix(strings.ToLower(fqdn), ".")
+	case "IPV4", "IPV6":
+		_, network, err := net.ParseCIDR(fqdn)
+		if err != nil {
+			return errorf(http.StatusBadRequest, codeProto, "Invalid value for fqdn: %s is not a network", fqdn)
+		}
+		obj["fqdn"] = network.String()
+	default:
+		return errorf(http.StatusBadRequest, codeProto, "Invalid value for zone_format: %v", obj["zone_format"])
+	}
+	obj["dns_fqdn"] = obj["fqdn"]
+	if obj["locked"] == true {
+		obj["locked_by"] = Username
+	} else {
+		delete(obj, "locked_by")
+	}
+	return nil
+}
+
+// prepareZoneAuth - prepares an authoritative zone, checking its access controls and completing the DNSSEC key
+// parameters written with the grid defaults, as NIOS does for the members of the structure left out
+func prepareZoneAuth(server *Server, obj, previous object) *wapiError {
+	if wapiErr := server.checkAccessLists(obj, "allow_query", "allow_transfer", "allow_update", "update_forwarding"); wapiErr != nil {
+		return wapiErr
+	}
+	keyParams := defaultDNSSecKeyParams()
+	if written, ok := obj["dnssec_key_params"].(map[string]interface{}); ok {
+		for name, value := range written {
+			if _, ok := keyParams[name]; !ok {
+				return unknownField("dnssec_key_params." + name)
+			}
+			keyParams[name] = value
+		}
+	}
+	obj["dnssec_key_params"] = keyParams
+	return prepareZone(server, obj, previous)
+}
+
+// objectTypeName - returns the type of a stored object, empty for an object not yet stored
+func objectTypeName(obj object) string {
+	ref, _ := obj["_ref"].(string)
+	if ref == "" {
+		return ""
+	}
+	return objectTypeOf(ref)
+}
+
+// removingZone - deletes the records of an authoritative zone along with it, as NIOS does
+func removingZone(server *Server, obj object, query map[string][]string) *wapiError {
+	fqdn, view := valueString(obj["fqdn"]), valueString(obj["view"])
+	for _, id := range append([]string{}, server.order...) {
+		record := server.objects[id]
+		if strings.HasPrefix(objectTypeName(record), "record:") && record["zone"] == fqdn && record["view"] == view {
+			server.drop(id)
+		}
+	}
+	return nil
+}
+
+// preparePermission - checks a permission applies to a group or a role, on an object or a resource type
+func preparePermission(server *Server, obj, previous object) *wapiError {
+	if valueString(obj["group"]) == "" && valueString(obj["role"]) == "" {
+		return errorf(http.StatusBadRequest, codeProto, "Either group or role must be set")
+	}
+	if valueString(obj["object"]) == "" && valueString(obj["resource_type"]) == "" {
+		return errorf(http.StatusBadRequest, codeProto, "Either object or resource_type must be set")
+	}
+	if role := valueString(obj["role"]); role != "" && server.findByName("adminrole", role) == nil {
+		return errorf(http.StatusBadRequest, codeData, "Admin role %s not found", role)
+	}
+	return nil
+}
+
+// removingDefault - refuses to delete the default view or network view
+func removingDefault(server *Server, obj object, query map[string][]string) *wapiError {
+	if obj["is_default"] == true {
+		return errorf(http.StatusBadRequest, codeData, "The default %s cannot be deleted", objectTypeName(obj))
+	}
+	return nil
+}
+
+// prepareView - checks the network view and the access controls of a DNS view
+func prepareView(server *Server, obj, previous object) *wapiError {
+	if networkView := valueString(obj["network_view"]); server.findByName("networkview", networkView) == nil {
+		return errorf(http.StatusBadRequest, codeData, "Network view %s not found", networkView)
+	}
+	return server.checkAccessLists(obj, "match_clients", "match_destinations")
+}
+
+// viewCreated - lists a new DNS view in the associated DNS views of its network view
+func viewCreated(server *Server, obj object) {
+	server.associateDNSViews(nil)
+}
+
+// viewUpdated - moves a DNS view renamed or moved to another network view in the associated DNS views
+func viewUpdated(server *Server, obj object, changed map[string]bool) {
+	if changed["name"] || changed["network_view"] {
+		server.associateDNSViews(nil)
+	}
+}
+
+// removingView - refuses to delete the default DNS view, else drops it from the associated DNS views of its
+// network view
+func removingView(server *Server, obj object, query map[string][]string) *wapiError {
+	if wapiErr := removingDefault(server, obj, query); wapiErr != nil {
+		return wapiErr
+	}
+	server.associateDNSViews(obj)
+	return nil
+}
+
+// associateDNSViews - computes the associated DNS views of every network view, leaving out the DNS view being removed
+func (server *Server) associateDNSViews(removed object) {
+	for _, networkView := range server.all("networkview") {
+		dnsViews := make([]interface{}, 0)
+		for _, dnsView := range server.all("view") {
+			if dnsView["network_view"] == networkView["name"] && (removed == nil || dnsView["_ref"] != removed["_ref"]) {
+				dnsViews = append(dnsViews, dnsView["name"])
+			}
+		}
+		networkView["associated_dns_views"] = dnsViews
+	}
+}
+
+// removingNetworkView - refuses to delete the default network view, else deletes the networks, network containers
+// and ranges of the network view along with it, as NIOS does
+func removingNetworkView(server *Server, obj object, query map[string][]string) *wapiError {
+	if wapiErr := removingDefault(server, obj, query); wapiErr != nil {
+		return wapiErr
+	}
+	for _, typeName := range []string{"range", "network", "networkcontainer", "ipv6network", "ipv6networkcontainer"} {
+		for _, child := range server.all(typeName) {
+			if child["network_view"] == obj["name"] {
+				server.drop(idOf(child["_ref"].(string)))
+			}
+		}
+	}
+	return nil
+}
+
+// prepareNamedACL - checks the access list of a named ACL and explodes the named ACLs it nests
+func prepareNamedACL(server *Server, obj, previous object) *wapiError {
+	if wapiErr := server.checkAccessLists(obj, "access_list"); wapiErr != nil {
+		return wapiErr
+	}
+	exploded := make([]interface{}, 0)
+	for _, entry := range fieldList(obj, "access_list") {
+		if ref, ok := entry.(string); ok {
+			nested, _ := server.lookup(ref)
+			exploded = append(exploded, fieldList(nested, "exploded_access_list")...)
+			continue
+		}
+		exploded = append(exploded, entry)
+	}
+	obj["exploded_access_list"] = exploded
+	return nil
+}
+
+// checkAccessLists - checks the entries of access control fields are addressac or tsigac structs, or references to
+// existing named ACLs
+func (server *Server) checkAccessLists(obj object, fields ...string) *wapiError {
+	for _, field := range fields {
+		for _, entry := range fieldList(obj, field) {
+			switch value := entry.(type) {
+			case string:
+				if _, ok := server.lookup(value); !ok || objectTypeOf(value) != "namedacl" {
+					return errorf(http.StatusBadRequest, codeData, "Invalid value for %s: named ACL %s not found", field, value)
+				}
+			case map[string]interface{}:
+				if value["_struct"] != "addressac" && value["_struct"] != "tsigac" {
+					return errorf(http.StatusBadRequest, codeProto, "Invalid value for %s: unknown struct %v", field, value["_struct"])
+				}
+			default:
+				return errorf(http.StatusBadRequest, codeProto, "Invalid value for %s: %v", field, entry)
+			}
+		}
+	}
+	return nil
+}
+
+// fieldList - returns the list value of a field of an object, empty when the field isn't set
+func fieldList(obj object, field string) []interface{} {
+	list, _ := obj[field].([]interface{})
+	return list
+}
+
+// checkView - fails when the DNS view of an object doesn't exist
+func (server *Server) checkView(obj object) *wapiError {
+	view := valueString(obj["view"])
+	if view != "" && server.findByName("view", view) == nil {
+		return errorf(http.StatusNotFound, codeNotFound, "View %s not found", view)
+	}
+	return nil
+}
+
+// findByName - returns the object of the type with the given name, nil when there is none
+func (server *Server) findByName(objectTypeName, name string) object {
+	for _, obj := range server.all(objectTypeName) {
+		if obj["name"] == name {
+			return obj
+		}
+	}
+	return nil
+}
+
+// zoneOf - returns the authoritative zone a DNS name belongs to: the closest zone_auth of the view, or the
+// parent domain of the name when the grid has none
+func (server *Server) zoneOf(name, view string) string {
+	zone := ""
+	for _, obj := range server.all("zone_auth") {
+		fqdn := valueString(obj["fqdn"])
+		if obj["view"] != view || obj["zone_format"] != "FORWARD" {
+			continue
+		}
+		if (name == fqdn || strings.HasSuffix(name, "."+fqdn)) && len(fqdn) > len(zone) {
+			zone = fqdn
+		}
+	}
+	if zone == "" {
+		if i := strings.Index(name, "."); i >= 0 {
+			zone = name[i+1:]
+		}
+	}
+	return zone
+}
+
+// checkAddress - fails when an address field is set to something which isn't an IP address
+func checkAddress(obj object, field string) *wapiError {
+	value, ok := obj[field]
+	if !ok {
+		return nil
+	}
+	ip := net.ParseIP(valueString(value))
+	if ip == nil || (field == "ipv4addr" && ip.To4() == nil) || (field == "ipv6addr" && ip.To4() != nil) {
+		return errorf(http.StatusBadRequest, codeProto, "Invalid value for %s: %v", field, value)
+	}
+	return nil
+}
+
+// reverseName - returns the in-addr.arpa or ip6.arpa name of an address
+func reverseName(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa", ip4[3], ip4[2], ip4[1], ip4[0])
+	}
+	const hexDigits = "0123456789abcdef"
+	labels := make([]string, 0, 32)
+	for i := len(ip) - 1; i >= 0; i-- {
+		labels = append(labels, string(hexDigits[ip[i]&0x0f]), string(hexDigits[ip[i]>>4]))
+	}
+	return strings.Join(labels, ".") + ".ip6.arpa"
+}
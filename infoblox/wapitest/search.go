@@ -0,0 +1,247 @@
+package wapitest
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// search - answers a GET on an object type with the objects matching the search filters of the query
+func (server *Server) search(objectType *objectType, query map[string][]string) (int, interface{}, *wapiError) {
+	filters, wapiErr := parseFilters(objectType, query)
+	if wapiErr != nil {
+		return 0, nil, wapiErr
+	}
+	maxResults := 1000
+	if value := firstValue(query, "_max_results"); value != "" {
+		var err error
+		maxResults, err = strconv.Atoi(value)
+		if err != nil || maxResults == 0 {
+			return 0, nil, errorf(http.StatusBadRequest, codeProto, "Invalid value for _max_results: %s", value)
+		}
+	}
+
+	results := make([]interface{}, 0)
+	for _, obj := range server.all(objectType.name) {
+		if !matchesFilters(obj, filters) {
+			continue
+		}
+		result, wapiErr := returnFields(objectType, obj, query)
+		if wapiErr != nil {
+			return 0, nil, wapiErr
+		}
+		results = append(results, result)
+	}
+	if maxResults < 0 && len(results) > -maxResults {
+		return 0, nil, errorf(http.StatusBadRequest, codeProto, "Result set too large (> %d)", -maxResults)
+	}
+	if maxResults > 0 && len(results) > maxResults {
+		results = results[:maxResults]
+	}
+	return http.StatusOK, results, nil
+}
+
+// filter - a search argument, e.g. name~=^foo for a regular expression match of name
+type filter struct {
+	field           string
+	extAttr         bool
+	regex           *regexp.Regexp
+	caseInsensitive bool
+	negate          bool
+	value           string
+}
+
+// parseFilters - parses the search arguments of a query, the arguments starting with _ being options
+func parseFilters(objectType *objectType, query map[string][]string) ([]filter, *wapiError) {
+	filters := make([]filter, 0)
+	for key, values := range query {
+		if strings.HasPrefix(key, "_") {
+			continue
+		}
+		for _, value := range values {
+			f := filter{value: value}
+			modifiers := ""
+			f.field = strings.TrimRightFunc(key, func(r rune) bool {
+				if strings.ContainsRune("~:!<>", r) {
+					modifiers += string(r)
+					return true
+				}
+				return false
+			})
+			if strings.HasPrefix(f.field, "*") {
+				f.field = strings.TrimPrefix(f.field, "*")
+				f.extAttr = true
+			} else if !objectType.hasField(f.field) && !objectType.hasSearchAlias(f.field) {
+				return nil, unknownField(f.field)
+			}
+			f.caseInsensitive = strings.Contains(modifiers, ":")
+			f.negate = strings.Contains(modifiers, "!")
+			if strings.Contains(modifiers, "~") {
+				expression := value
+				if f.caseInsensitive {
+					expression = "(?i)" + expression
+				}
+				regex, err := regexp.Compile(expression)
+				if err != nil {
+					return nil, errorf(http.StatusBadRequest, codeProto, "Invalid regular expression %s: %s", value, err)
+				}
+				f.regex = regex
+			}
+			filters = append(filters, f)
+		}
+	}
+	return filters, nil
+}
+
+func matchesFilters(obj object, filters []filter) bool {
+	for _, f := range filters {
+		var candidates []string
+		if f.extAttr {
+			extAttrs, _ := obj["extattrs"].(map[string]interface{})
+			if extAttr, ok := extAttrs[f.field].(map[string]interface{}); ok {
+				candidates = []string{valueString(extAttr["value"])}
+			}
+		} else {
+			candidates = fieldStrings(obj, f.field)
+		}
+		matched := false
+		for _, candidate := range candidates {
+			if f.matches(candidate) {
+				matched = true
+				break
+			}
+		}
+		if matched == f.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (f filter) matches(candidate string) bool {
+	if f.regex != nil {
+		return f.regex.MatchString(candidate)
+	}
+	if f.caseInsensitive {
+		return strings.EqualFold(candidate, f.value)
+	}
+	return candidate == f.value
+}
+
+// fieldStrings - returns the values a search on field compares to: the field value, each element of a list
+// field, or the field of the same name of each struct in a list, e.g. ipv4addr of the ipv4addrs of a host
+func fieldStrings(obj object, field string) []string {
+	if value, ok := obj[field]; ok {
+		if list, ok := value.([]interface{}); ok {
+			values := make([]string, 0, len(list))
+			for _, element := range list {
+				values = append(values, valueString(element))
+			}
+			return values
+		}
+		return []string{valueString(value)}
+	}
+	values := make([]string, 0)
+	for _, value := range obj {
+		list, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, element := range list {
+			if structValue, ok := element.(map[string]interface{}); ok {
+				if fieldValue, ok := structValue[field]; ok {
+					values = append(values, valueString(fieldValue))
+				}
+			}
+		}
+	}
+	return values
+}
+
+// returnFields - builds the response for an object from the _return_fields or _return_fields+ of the query,
+// falling back on the basic fields of the object type
+func returnFields(objectType *objectType, obj object, query map[string][]string) (map[string]interface{}, *wapiError) {
+	fields := objectType.basicFields
+	if values, ok := query["_return_fields"]; ok {
+		fields = splitFields(values)
+	} else if values, ok := query["_return_fields+"]; ok {
+		fields = append(append([]string{}, objectType.basicFields...), splitFields(values)...)
+	}
+
+	response := map[string]interface{}{"_ref": obj["_ref"]}
+	for _, field := range fields {
+		if !objectType.hasField(field) {
+			return nil, unknownField(field)
+		}
+		if objectType.isWriteOnly(field) {
+			return nil, errorf(http.StatusBadRequest, codeProto, "Field is not readable: %s", field)
+		}
+		if value, ok := obj[field]; ok {
+			response[field] = copyValue(value)
+		}
+	}
+	return response, nil
+}
+
+func splitFields(values []string) []string {
+	fields := make([]string, 0)
+	for _, value := range values {
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return fields
+}
+
+// valueString - returns the string form of a field value as a search argument spells it
+func valueString(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+// copyValue - deep copies a value decoded from JSON, so stored objects don't share maps and slices with requests
+func copyValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(typed))
+		for key, element := range typed {
+			copied[key] = copyValue(element)
+		}
+		return copied
+	case object:
+		return copyValue(map[string]interface{}(typed))
+	case []interface{}:
+		copied := make([]interface{}, len(typed))
+		for i, element := range typed {
+			copied[i] = copyValue(element)
+		}
+		return copied
+	case []string:
+		copied := make([]interface{}, len(typed))
+		for i, element := range typed {
+			copied[i] = element
+		}
+		return copied
+	case int:
+		return float64(typed)
+	default:
+		return value
+	}
+}
+
+func equalValues(a, b interface{}) bool {
+	return reflect.DeepEqual(copyValue(a), copyValue(b))
+}
@@ -0,0 +1,612 @@
+// Package wapitest provides an in-process fake of the WAPI of an Infoblox grid, built on httptest, so the
+// provider can be tested without an appliance. It stores objects, issues references and answers the
+// requests of the provider the way a grid master does: _schema, _return_fields, search filters,
+// object functions, POST, PUT, DELETE and WAPI error bodies.
+package wapitest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// Username - the user the fake grid authenticates
+	Username = "admin"
+	// Password - the password of Username
+	Password = "infoblox"
+	// sessionCookieName - the cookie NIOS returns once a request is authenticated
+	sessionCookieName = "ibapauth"
+	// maxCommentLength - the longest comment NIOS accepts
+	maxCommentLength = 256
+)
+
+// SupportedVersions - the WAPI versions the fake grid supports
+var SupportedVersions = []string{"1.0", "2.0", "2.3", "2.5", "2.6", "2.6.1", "2.7"}
+
+// Server - a fake grid master. Requests are served by the embedded httptest.Server, whose URL is the
+// server setting of the provider.
+type Server struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	objects  map[string]object
+	order    []string
+	nextID   int
+	sessions map[string]bool
+}
+
+// object - the fields of a stored WAPI object, as decoded from JSON, along with its _ref
+type object map[string]interface{}
+
+// NewServer - starts a fake grid master holding the objects a new grid has, e.g. the default views.
+// The caller must Close it.
+func NewServer() *Server {
+	server := &Server{
+		objects:  make(map[string]object),
+		sessions: make(map[string]bool),
+	}
+	for _, seed := range seedObjects {
+		ref, wapiErr := server.create(seed.objectType, seed.fields)
+		if wapiErr != nil {
+			panic(fmt.Sprintf("wapitest: could not seed %s: %s", seed.objectType, wapiErr))
+		}
+		obj, _ := server.lookup(ref)
+		for field, value := range seed.computed {
+			obj[field] = value
+		}
+		obj["_ref"] = objectTypes[seed.objectType].ref(idOf(ref), obj)
+	}
+	server.Server = httptest.NewServer(server)
+	return server
+}
+
+// Create - stores a new object, as if created through WAPI, and returns its reference. It lets tests set up
+// objects which exist on the grid before Terraform runs.
+func (server *Server) Create(objectType string, fields map[string]interface{}) (string, error) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	ref, wapiErr := server.create(objectType, fields)
+	if wapiErr != nil {
+		return "", wapiErr
+	}
+	return ref, nil
+}
+
+// Delete - removes an object, as if deleted outside Terraform
+func (server *Server) Delete(ref string) error {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	_, wapiErr := server.remove(ref, nil)
+	if wapiErr != nil {
+		return wapiErr
+	}
+	return nil
+}
+
+// Get - returns the fields of an object, nil when it doesn't exist
+func (server *Server) Get(ref string) map[string]interface{} {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	obj, ok := server.lookup(ref)
+	if !ok {
+		return nil
+	}
+	return copyValue(map[string]interface{}(obj)).(map[string]interface{})
+}
+
+// ServeHTTP - authenticates a WAPI request then dispatches it on its path and method
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !server.authenticate(w, r) {
+		return
+	}
+
+	version, path, ok := splitWapiPath(r.URL.Path)
+	if !ok {
+		writeError(w, errorf(http.StatusNotFound, codeProto, "Unknown WAPI path %s", r.URL.Path))
+		return
+	}
+	if !versionSupported(version) {
+		writeError(w, errorf(http.StatusBadRequest, codeProto, "Version %s not supported", version))
+		return
+	}
+
+	query := r.URL.Query()
+	if _, ok := query["_schema"]; ok {
+		server.serveSchema(w, version, path)
+		return
+	}
+	if path == "logout" && r.Method == http.MethodPost {
+		server.logout(r)
+		writeJSON(w, http.StatusOK, "")
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		payload, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, errorf(http.StatusBadRequest, codeProto, "Could not read the request body: %s", err))
+			return
+		}
+		if len(payload) > 0 {
+			if err := json.Unmarshal(payload, &body); err != nil {
+				writeError(w, errorf(http.StatusBadRequest, codeProto, "The request body is not a JSON object: %s", err))
+				return
+			}
+		}
+	}
+
+	if wapiErr := checkVersion(version, path, query, body); wapiErr != nil {
+		writeError(w, wapiErr)
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	status, response, wapiErr := server.dispatch(r.Method, path, query, body)
+	if wapiErr != nil {
+		writeError(w, wapiErr)
+		return
+	}
+	writeJSON(w, status, response)
+}
+
+func (server *Server) dispatch(method, path string, query map[string][]string, body map[string]interface{}) (int, interface{}, *wapiError) {
+	objectTypeName := path
+	isRef := false
+	if i := strings.Index(path, "/"); i >= 0 {
+		objectTypeName = path[:i]
+		isRef = true
+	}
+	objectType, ok := objectTypes[objectTypeName]
+	if !ok {
+		return 0, nil, errorf(http.StatusBadRequest, codeProto, "Unknown object type (%s)", objectTypeName)
+	}
+
+	if !isRef {
+		switch method {
+		case http.MethodGet:
+			return server.search(objectType, query)
+		case http.MethodPost:
+			ref, wapiErr := server.create(objectType.name, body)
+			if wapiErr != nil {
+				return 0, nil, wapiErr
+			}
+			return server.respondWithRef(http.StatusCreated, ref, query)
+		}
+		return 0, nil, errorf(http.StatusBadRequest, codeProto, "Method %s not allowed on %s", method, objectType.name)
+	}
+
+	switch method {
+	case http.MethodGet:
+		obj, ok := server.lookup(path)
+		if !ok {
+			return 0, nil, notFound(path)
+		}
+		response, wapiErr := returnFields(objectType, obj, query)
+		if wapiErr != nil {
+			return 0, nil, wapiErr
+		}
+		return http.StatusOK, response, nil
+	case http.MethodPut:
+		ref, wapiErr := server.update(path, body)
+		if wapiErr != nil {
+			return 0, nil, wapiErr
+		}
+		return server.respondWithRef(http.StatusOK, ref, query)
+	case http.MethodDelete:
+		ref, wapiErr := server.remove(path, query)
+		if wapiErr != nil {
+			return 0, nil, wapiErr
+		}
+		return http.StatusOK, ref, nil
+	case http.MethodPost:
+		functionName := firstValue(query, "_function")
+		if functionName == "" {
+			return 0, nil, errorf(http.StatusBadRequest, codeProto, "POST on an object reference needs a _function argument")
+		}
+		obj, ok := server.lookup(path)
+		if !ok {
+			return 0, nil, notFound(path)
+		}
+		function, ok := objectType.functions[functionName]
+		if !ok {
+			return 0, nil, errorf(http.StatusBadRequest, codeProto, "Function %s is not valid for this object", functionName)
+		}
+		if body == nil {
+			body = make(map[string]interface{})
+		}
+		response, wapiErr := function(server, obj, body)
+		if wapiErr != nil {
+			return 0, nil, wapiErr
+		}
+		return http.StatusOK, response, nil
+	}
+	return 0, nil, errorf(http.StatusBadRequest, codeProto, "Method %s not allowed on %s", method, path)
+}
+
+// respondWithRef - answers a write with the reference of the object, or its return fields when asked for
+func (server *Server) respondWithRef(status int, ref string, query map[string][]string) (int, interface{}, *wapiError) {
+	if _, ok := query["_return_fields"]; !ok {
+		if _, ok := query["_return_fields+"]; !ok {
+			return status, ref, nil
+		}
+	}
+	obj, _ := server.lookup(ref)
+	response, wapiErr := returnFields(objectTypes[objectTypeOf(ref)], obj, query)
+	if wapiErr != nil {
+		return 0, nil, wapiErr
+	}
+	return status, response, nil
+}
+
+// create - validates and stores a new object of the given type
+func (server *Server) create(objectTypeName string, fields map[string]interface{}) (string, *wapiError) {
+	objectType, ok := objectTypes[objectTypeName]
+	if !ok {
+		return "", errorf(http.StatusBadRequest, codeProto, "Unknown object type (%s)", objectTypeName)
+	}
+	obj := make(object)
+	for field, value := range fields {
+		if field == "_ref" {
+			continue
+		}
+		if !objectType.hasField(field) {
+			return "", unknownField(field)
+		}
+		if objectType.isReadOnly(field) {
+			return "", errorf(http.StatusBadRequest, codeProto, "Field is not writable: %s", field)
+		}
+		obj[field] = copyValue(value)
+	}
+	if wapiErr := server.resolveFunctions(obj); wapiErr != nil {
+		return "", wapiErr
+	}
+	for field, value := range objectType.defaults {
+		if _, ok := obj[field]; !ok {
+			obj[field] = copyValue(value)
+		}
+	}
+	if wapiErr := checkFields(objectType, obj); wapiErr != nil {
+		return "", wapiErr
+	}
+	if wapiErr := server.checkExtAttrs(obj); wapiErr != nil {
+		return "", wapiErr
+	}
+	if objectType.prepare != nil {
+		if wapiErr := objectType.prepare(server, obj, nil); wapiErr != nil {
+			return "", wapiErr
+		}
+	}
+	if wapiErr := server.checkConflict(objectType, obj, ""); wapiErr != nil {
+		return "", wapiErr
+	}
+
+	server.nextID++
+	id := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("fake$%s$%d", objectType.name, server.nextID)))
+	server.objects[id] = obj
+	server.order = append(server.order, id)
+	obj["_ref"] = objectType.ref(id, obj)
+	if objectType.created != nil {
+		objectType.created(server, obj)
+	}
+	return obj["_ref"].(string), nil
+}
+
+// update - merges the fields of a PUT into a stored object
+func (server *Server) update(ref string, fields map[string]interface{}) (string, *wapiError) {
+	obj, ok := server.lookup(ref)
+	if !ok {
+		return "", notFound(ref)
+	}
+	objectType := objectTypes[objectTypeOf(ref)]
+	updated := copyValue(map[string]interface{}(obj)).(map[string]interface{})
+	changed := make(map[string]bool)
+	for field, value := range fields {
+		if field == "_ref" {
+			continue
+		}
+		if !objectType.hasField(field) {
+			return "", unknownField(field)
+		}
+		if objectType.isReadOnly(field) {
+			return "", errorf(http.StatusBadRequest, codeProto, "Field is not writable: %s", field)
+		}
+		updated[field] = copyValue(value)
+		changed[field] = true
+	}
+	if wapiErr := server.resolveFunctions(updated); wapiErr != nil {
+		return "", wapiErr
+	}
+	if wapiErr := checkFields(objectType, updated); wapiErr != nil {
+		return "", wapiErr
+	}
+	if wapiErr := server.checkExtAttrs(updated); wapiErr != nil {
+		return "", wapiErr
+	}
+	if objectType.prepare != nil {
+		if wapiErr := objectType.prepare(server, updated, obj); wapiErr != nil {
+			return "", wapiErr
+		}
+	}
+	id := idOf(ref)
+	if wapiErr := server.checkConflict(objectType, updated, id); wapiErr != nil {
+		return "", wapiErr
+	}
+	updated["_ref"] = objectType.ref(id, updated)
+	server.objects[id] = updated
+	if objectType.updated != nil {
+		objectType.updated(server, updated, changed)
+	}
+	return updated["_ref"].(string), nil
+}
+
+// checkFields - checks the constraints common to every object type: required fields and the length of comments
+func checkFields(objectType *objectType, obj object) *wapiError {
+	for _, field := range objectType.required {
+		if _, ok := obj[field]; !ok {
+			return errorf(http.StatusBadRequest, codeProto, "Field is required: %s", field)
+		}
+	}
+	if comment, ok := obj["comment"].(string); ok && len(comment) > maxCommentLength {
+		return errorf(http.StatusBadRequest, codeProto, "Invalid value for comment: the maximum length is %d", maxCommentLength)
+	}
+	return nil
+}
+
+// checkExtAttrs - checks the extensible attribute values of an object against the type of their definition,
+// NIOS rejects an INTEGER value sent as a string
+func (server *Server) checkExtAttrs(obj object) *wapiError {
+	extAttrs, _ := obj["extattrs"].(map[string]interface{})
+	for name, value := range extAttrs {
+		extAttr, _ := value.(map[string]interface{})
+		definition := server.findByName("extensibleattributedef", name)
+		if extAttr == nil || definition == nil || definition["type"] != "INTEGER" {
+			continue
+		}
+		if _, ok := extAttr["value"].(float64); !ok {
+			return errorf(http.StatusBadRequest, codeProto, "Invalid value for extattrs %s: %v is not an integer", name, extAttr["value"])
+		}
+	}
+	return nil
+}
+
+// remove - deletes a stored object, along with the objects it owns
+func (server *Server) remove(ref string, query map[string][]string) (string, *wapiError) {
+	obj, ok := server.lookup(ref)
+	if !ok {
+		return "", notFound(ref)
+	}
+	objectType := objectTypes[objectTypeOf(ref)]
+	if objectType.removing != nil {
+		if wapiErr := objectType.removing(server, obj, query); wapiErr != nil {
+			return "", wapiErr
+		}
+	}
+	server.drop(idOf(ref))
+	return obj["_ref"].(string), nil
+}
+
+func (server *Server) drop(id string) {
+	delete(server.objects, id)
+	for i, orderedID := range server.order {
+		if orderedID == id {
+			server.order = append(server.order[:i], server.order[i+1:]...)
+			return
+		}
+	}
+}
+
+// lookup - finds an object from its reference. Only the id of the reference is matched, like NIOS
+// does, so references whose readable part is stale still resolve.
+func (server *Server) lookup(ref string) (object, bool) {
+	obj, ok := server.objects[idOf(ref)]
+	if !ok || objectTypeOf(obj["_ref"].(string)) != objectTypeOf(ref) {
+		return nil, false
+	}
+	return obj, true
+}
+
+// all - returns the objects of a type in creation order
+func (server *Server) all(objectTypeName string) []object {
+	objects := make([]object, 0)
+	for _, id := range server.order {
+		obj := server.objects[id]
+		if objectTypeOf(obj["_ref"].(string)) == objectTypeName {
+			objects = append(objects, obj)
+		}
+	}
+	return objects
+}
+
+// checkConflict - fails when another object of the type has the same key fields, as NIOS refuses duplicates
+func (server *Server) checkConflict(objectType *objectType, obj object, id string) *wapiError {
+	if len(objectType.keyFields) == 0 {
+		return nil
+	}
+	for _, other := range server.all(objectType.name) {
+		if idOf(other["_ref"].(string)) == id {
+			continue
+		}
+		same := true
+		for _, field := range objectType.keyFields {
+			if !equalValues(obj[field], other[field]) {
+				same = false
+				break
+			}
+		}
+		if same {
+			return errorf(http.StatusBadRequest, codeConflict, "The %s %s already exists.", objectType.name, objectType.display(obj))
+		}
+	}
+	return nil
+}
+
+// authenticate - accepts requests carrying a session cookie the server issued, or the basic auth credentials
+// of Username, which open a new session
+func (server *Server) authenticate(w http.ResponseWriter, r *http.Request) bool {
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		server.mutex.Lock()
+		valid := server.sessions[cookie.Value]
+		server.mutex.Unlock()
+		if valid {
+			return true
+		}
+	}
+	user, password, ok := r.BasicAuth()
+	if !ok || user != Username || password != Password {
+		w.Header().Set("WWW-Authenticate", `Basic realm="InfoBlox ONE Platform"`)
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, "<html><body>Authorization Required</body></html>")
+		return false
+	}
+	token := make([]byte, 16)
+	rand.Read(token)
+	session := hex.EncodeToString(token)
+	server.mutex.Lock()
+	server.sessions[session] = true
+	server.mutex.Unlock()
+	http.SetCookie(w, &http.Cookie{Name: sessionCookieName, Value: session, Path: "/", HttpOnly: true})
+	return true
+}
+
+// ExpireSessions - ends every session, as when they time out on the grid, so the next requests must log in again
+func (server *Server) ExpireSessions() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.sessions = make(map[string]bool)
+}
+
+func (server *Server) logout(r *http.Request) {
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		server.mutex.Lock()
+		delete(server.sessions, cookie.Value)
+		server.mutex.Unlock()
+	}
+}
+
+// serveSchema - answers the _schema requests for the supported versions, the supported objects and the
+// fields of an object type
+func (server *Server) serveSchema(w http.ResponseWriter, version, path string) {
+	if path == "" {
+		supportedObjects := make([]string, 0, len(objectTypes))
+		for name, objectType := range objectTypes {
+			if objectType.inVersion(version) {
+				supportedObjects = append(supportedObjects, name)
+			}
+		}
+		sort.Strings(supportedObjects)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"requested_version":  version,
+			"supported_objects":  supportedObjects,
+			"supported_versions": SupportedVersions,
+		})
+		return
+	}
+	objectType, ok := objectTypes[path]
+	if !ok || !objectType.inVersion(version) {
+		writeError(w, errorf(http.StatusBadRequest, codeProto, "Unknown object type (%s)", path))
+		return
+	}
+	fields := make([]map[string]string, 0, len(objectType.fields))
+	for _, field := range objectType.fields {
+		if !objectType.hasFieldIn(field, version) {
+			continue
+		}
+		supports := "rwus"
+		if objectType.isReadOnly(field) {
+			supports = "rs"
+		}
+		if objectType.isWriteOnly(field) {
+			supports = "w"
+		}
+		fields = append(fields, map[string]string{"name": field, "supports": supports})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"type":    objectType.name,
+		"version": version,
+		"fields":  fields,
+	})
+}
+
+// checkVersion - rejects a request on an object type, or reading or writing fields, the WAPI version lacks
+func checkVersion(version, path string, query map[string][]string, body map[string]interface{}) *wapiError {
+	objectType, ok := objectTypes[strings.SplitN(path, "/", 2)[0]]
+	if !ok {
+		return nil
+	}
+	if !objectType.inVersion(version) {
+		return errorf(http.StatusBadRequest, codeProto, "Unknown object type (%s)", objectType.name)
+	}
+	fields := splitFields(append(query["_return_fields"], query["_return_fields+"]...))
+	for field := range body {
+		fields = append(fields, field)
+	}
+	for _, field := range fields {
+		if objectType.hasField(field) && !objectType.hasFieldIn(field, version) {
+			return unknownField(field)
+		}
+	}
+	return nil
+}
+
+// splitWapiPath - splits /wapi/v2.6.1/record:a/ZG5z... into the version and the path after it
+func splitWapiPath(path string) (string, string, bool) {
+	if !strings.HasPrefix(path, "/wapi/v") {
+		return "", "", false
+	}
+	rest := strings.TrimPrefix(path, "/wapi/v")
+	i := strings.Index(rest, "/")
+	if i < 0 {
+		return "", "", false
+	}
+	return rest[:i], strings.Trim(rest[i+1:], "/"), true
+}
+
+func versionSupported(version string) bool {
+	for _, supported := range SupportedVersions {
+		if version == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// objectTypeOf - returns the object type of a reference, e.g. record:a for record:a/ZG5z...:foo.example.com/default
+func objectTypeOf(ref string) string {
+	if i := strings.Index(ref, "/"); i >= 0 {
+		return ref[:i]
+	}
+	return ref
+}
+
+// idOf - returns the id of a reference, between the object type and the readable part
+func idOf(ref string) string {
+	id := strings.TrimPrefix(ref, objectTypeOf(ref)+"/")
+	if i := strings.Index(id, ":"); i >= 0 {
+		id = id[:i]
+	}
+	return id
+}
+
+func firstValue(query map[string][]string, key string) string {
+	if values := query[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func writeJSON(w http.ResponseWriter, status int, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,209 @@
+package wapitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func doRequest(t *testing.T, server *Server, method, path string, body interface{}, response interface{}) int {
+	var payload bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&payload).Encode(body)
+	}
+	req, err := http.NewRequest(method, server.URL+"/wapi/v2.6.1/"+path, &payload)
+	assert.Nil(t, err)
+	req.SetBasicAuth(Username, Password)
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	if response != nil {
+		json.NewDecoder(resp.Body).Decode(response)
+	}
+	return resp.StatusCode
+}
+
+func TestCreateAndSearch(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	var ref string
+	status := doRequest(t, server, http.MethodPost, "record:a", map[string]interface{}{"name": "foo.example.com", "ipv4addr": "10.0.0.1"}, &ref)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Regexp(t, "^record:a/[^:]+:foo.example.com/default$", ref)
+
+	var results []map[string]interface{}
+	status = doRequest(t, server, http.MethodGet, "record:a?name~=^foo&_return_fields=name,zone", nil, &results)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []map[string]interface{}{{"_ref": ref, "name": "foo.example.com", "zone": "example.com"}}, results)
+
+	status = doRequest(t, server, http.MethodGet, "record:a?name=bar.example.com", nil, &results)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, results)
+}
+
+func TestErrors(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	var wapiErr map[string]string
+	status := doRequest(t, server, http.MethodPost, "record:a", map[string]interface{}{"name": "foo.example.com", "ipv4addr": "10.0.0.1", "bogus": true}, &wapiErr)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "Client.Ibap.Proto", wapiErr["code"])
+
+	status = doRequest(t, server, http.MethodGet, "record:a/ZmFrZQ:foo.example.com/default", nil, &wapiErr)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "Client.Ibap.Data.NotFound", wapiErr["code"])
+
+	doRequest(t, server, http.MethodPost, "record:cname", map[string]interface{}{"name": "foo.example.com", "canonical": "bar.example.com"}, nil)
+	status = doRequest(t, server, http.MethodPost, "record:cname", map[string]interface{}{"name": "foo.example.com", "canonical": "baz.example.com"}, &wapiErr)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "Client.Ibap.Data.Conflict", wapiErr["code"])
+}
+
+func TestNextAvailable(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	_, err := server.Create("networkcontainer", map[string]interface{}{"network": "10.1.0.0/16"})
+	assert.Nil(t, err)
+	nextAvailableNetwork := map[string]interface{}{
+		"_object_function":   "next_available_network",
+		"_result_field":      "networks",
+		"_object":            "networkcontainer",
+		"_object_parameters": map[string]interface{}{"network": "10.1.0.0/16"},
+		"_parameters":        map[string]interface{}{"cidr": 24, "exclude": []string{"10.1.0.0/24"}},
+	}
+	var network map[string]interface{}
+	status := doRequest(t, server, http.MethodPost, "network?_return_fields=network,network_container", map[string]interface{}{"network": nextAvailableNetwork}, &network)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "10.1.1.0/24", network["network"])
+	assert.Equal(t, "10.1.0.0/16", network["network_container"])
+
+	_, err = server.Create("record:a", map[string]interface{}{"name": "foo.example.com", "ipv4addr": "10.1.1.1"})
+	assert.Nil(t, err)
+	var ips map[string][]string
+	status = doRequest(t, server, http.MethodPost, network["_ref"].(string)+"?_function=next_available_ip", map[string]interface{}{"num": 2}, &ips)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"10.1.1.2", "10.1.1.3"}, ips["ips"])
+}
+
+func TestSessions(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/wapi/v2.6.1/view", nil)
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	req.SetBasicAuth(Username, Password)
+	resp, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	cookies := resp.Cookies()
+	assert.Len(t, cookies, 1)
+
+	req, _ = http.NewRequest(http.MethodGet, server.URL+"/wapi/v2.6.1/view", nil)
+	req.AddCookie(cookies[0])
+	resp, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	server.ExpireSessions()
+	resp, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestDNSSecOperation(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	ref, err := server.Create("zone_auth", map[string]interface{}{"fqdn": "example.com", "dnssec_key_params": map[string]interface{}{"next_secure_type": "NSEC"}})
+	assert.Nil(t, err)
+	zone := server.Get(ref)
+	assert.Equal(t, "NSEC", zone["dnssec_key_params"].(map[string]interface{})["next_secure_type"])
+	assert.Equal(t, "PRE_PUBLISH", zone["dnssec_key_params"].(map[string]interface{})["zsk_rollover_mechanism"])
+
+	status := doRequest(t, server, http.MethodPost, ref+"?_function=dnssec_operation", map[string]interface{}{"operation": "UNSIGN"}, nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status = doRequest(t, server, http.MethodPost, ref+"?_function=dnssec_operation", map[string]interface{}{"operation": "SIGN"}, nil)
+	assert.Equal(t, http.StatusOK, status)
+	var zones []map[string]interface{}
+	doRequest(t, server, http.MethodGet, "zone_auth?fqdn=example.com&_return_fields=is_dnssec_signed,dnssec_keys", nil, &zones)
+	assert.Equal(t, true, zones[0]["is_dnssec_signed"])
+	assert.Len(t, zones[0]["dnssec_keys"], 2)
+
+	status = doRequest(t, server, http.MethodPost, ref+"?_function=dnssec_operation", map[string]interface{}{"operation": "UNSIGN"}, nil)
+	assert.Equal(t, http.StatusOK, status)
+	doRequest(t, server, http.MethodGet, "zone_auth?fqdn=example.com&_return_fields=is_dnssec_signed,dnssec_keys", nil, &zones)
+	assert.Equal(t, false, zones[0]["is_dnssec_signed"])
+	assert.Empty(t, zones[0]["dnssec_keys"])
+}
+
+func TestNetworkViews(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	networkViewRef, err := server.Create("networkview", map[string]interface{}{"name": "tenant"})
+	assert.Nil(t, err)
+	viewRef, err := server.Create("view", map[string]interface{}{"name": "tenant-internal", "network_view": "tenant"})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"tenant-internal"}, server.Get(networkViewRef)["associated_dns_views"])
+	assert.Equal(t, []interface{}{"default"}, server.Get(server.findByName("networkview", "default")["_ref"].(string))["associated_dns_views"])
+
+	_, err = server.Create("network", map[string]interface{}{"network": "10.0.0.0/24", "network_view": "tenant"})
+	assert.Nil(t, err)
+	assert.Nil(t, server.Delete(viewRef))
+	assert.Equal(t, []interface{}{}, server.Get(networkViewRef)["associated_dns_views"])
+	assert.Nil(t, server.Delete(networkViewRef))
+	assert.Empty(t, server.all("network"))
+}
+
+func TestVersionedSchema(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	get := func(path string, response interface{}) int {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		assert.Nil(t, err)
+		req.SetBasicAuth(Username, Password)
+		resp, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		defer resp.Body.Close()
+		json.NewDecoder(resp.Body).Decode(response)
+		return resp.StatusCode
+	}
+
+	var schema struct {
+		SupportedObjects []string `json:"supported_objects"`
+	}
+	assert.Equal(t, http.StatusOK, get("/wapi/v2.0/?_schema", &schema))
+	assert.Contains(t, schema.SupportedObjects, "zone_auth")
+	assert.NotContains(t, schema.SupportedObjects, "namedacl")
+	assert.Equal(t, http.StatusOK, get("/wapi/v2.6.1/?_schema", &schema))
+	assert.Contains(t, schema.SupportedObjects, "namedacl")
+
+	var objectSchema struct {
+		Fields []map[string]string `json:"fields"`
+	}
+	assert.Equal(t, http.StatusOK, get("/wapi/v2.0/zone_auth?_schema", &objectSchema))
+	for _, field := range objectSchema.Fields {
+		assert.NotEqual(t, "update_forwarding", field["name"])
+	}
+
+	var wapiErr map[string]string
+	assert.Equal(t, http.StatusBadRequest, get("/wapi/v2.0/zone_auth?_return_fields=fqdn,update_forwarding", &wapiErr))
+	assert.Equal(t, "Unknown argument/field: 'update_forwarding'", wapiErr["text"])
+	assert.Equal(t, http.StatusBadRequest, get("/wapi/v2.0/namedacl", &wapiErr))
+	var zones []map[string]interface{}
+	assert.Equal(t, http.StatusOK, get("/wapi/v2.3/zone_auth?_return_fields=fqdn,update_forwarding", &zones))
+}